@@ -0,0 +1,19 @@
+package bean
+
+import "github.com/sword-demon/vtool/internal/bean"
+
+// CopySlice 将src中的每个元素复制为D类型，所有元素共用同一份复制计划
+// Options.Parallelism大于1时并行复制
+func CopySlice[S any, D any](src []S, opts ...Options) ([]D, error) {
+	return bean.CopySlice[S, D](src, opts...)
+}
+
+// CopyMap 将src中的每个值复制为D类型，返回键相同的新map
+func CopyMap[K comparable, S any, D any](src map[K]S, opts ...Options) (map[K]D, error) {
+	return bean.CopyMap[K, S, D](src, opts...)
+}
+
+// Convert 将src复制为新的D类型值
+func Convert[D any](src interface{}, opts ...Options) (D, error) {
+	return bean.Convert[D](src, opts...)
+}
@@ -8,8 +8,21 @@ type Converter = bean.Converter
 // Options 复制选项
 type Options = bean.Options
 
+// MatchMode 字段名称的匹配方式
+type MatchMode = bean.MatchMode
+
+const (
+	// MatchExact 字段名称完全一致才匹配
+	MatchExact = bean.MatchExact
+	// MatchIgnoreCase 忽略大小写匹配
+	MatchIgnoreCase = bean.MatchIgnoreCase
+	// MatchSnakeCamel 忽略大小写和下划线匹配，例如user_name与UserName
+	MatchSnakeCamel = bean.MatchSnakeCamel
+)
+
 // Copy 复制结构体
-// src 源结构体指针，dst 目标结构体指针
+// src 源结构体指针，dst 目标结构体指针，任一侧也可以是键为字符串的map
+// 字段可通过`copier:"name,omitempty,must"`标签指定匹配名称，`copier:"-"`表示跳过
 func Copy(src, dst interface{}, opts ...Options) error {
 	return bean.Copy(src, dst, opts...)
 }
@@ -23,3 +36,14 @@ func CopyWithoutNil(src, dst interface{}) error {
 func DeepCopy(src, dst interface{}) error {
 	return bean.DeepCopy(src, dst)
 }
+
+// StructToMap 将结构体转换为map[string]interface{}，键为标签名或字段名
+// 设置Options.DeepCopy时指针、切片和map字段写入副本，否则与源结构体共享内存
+func StructToMap(src interface{}, opts ...Options) (map[string]interface{}, error) {
+	return bean.StructToMap(src, opts...)
+}
+
+// MapToStruct 将map[string]interface{}复制到结构体，支持字符串与数字的弱类型转换
+func MapToStruct(src map[string]interface{}, dst interface{}, opts ...Options) error {
+	return bean.MapToStruct(src, dst, opts...)
+}
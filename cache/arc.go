@@ -0,0 +1,11 @@
+package cache
+
+import "github.com/sword-demon/vtool/internal/cache"
+
+// ARC 自适应替换缓存，线程安全
+type ARC[K comparable, V any] = cache.ARC[K, V]
+
+// NewARC 创建容量为capacity的ARC缓存
+func NewARC[K comparable, V any](capacity int, opts ...Options[K, V]) *ARC[K, V] {
+	return cache.NewARC(capacity, opts...)
+}
@@ -0,0 +1,12 @@
+package cache
+
+import "github.com/sword-demon/vtool/internal/cache"
+
+// Cache 容量有限的缓存接口，LRU、LFU和ARC均实现了该接口
+type Cache[K comparable, V any] = cache.Cache[K, V]
+
+// Options 缓存选项
+type Options[K comparable, V any] = cache.Options[K, V]
+
+// Stats 缓存命中统计
+type Stats = cache.Stats
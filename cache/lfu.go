@@ -0,0 +1,11 @@
+package cache
+
+import "github.com/sword-demon/vtool/internal/cache"
+
+// LFU 最不经常使用缓存，线程安全
+type LFU[K comparable, V any] = cache.LFU[K, V]
+
+// NewLFU 创建容量为capacity的LFU缓存
+func NewLFU[K comparable, V any](capacity int, opts ...Options[K, V]) *LFU[K, V] {
+	return cache.NewLFU(capacity, opts...)
+}
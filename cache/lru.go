@@ -0,0 +1,11 @@
+package cache
+
+import "github.com/sword-demon/vtool/internal/cache"
+
+// LRU 最近最少使用缓存，线程安全
+type LRU[K comparable, V any] = cache.LRU[K, V]
+
+// NewLRU 创建容量为capacity的LRU缓存
+func NewLRU[K comparable, V any](capacity int, opts ...Options[K, V]) *LRU[K, V] {
+	return cache.NewLRU(capacity, opts...)
+}
@@ -0,0 +1,18 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/sword-demon/vtool/internal/cache"
+)
+
+// TTLOptions 过期缓存选项
+type TTLOptions[K comparable, V any] = cache.TTLOptions[K, V]
+
+// TTLCache 按时间过期的缓存，线程安全
+type TTLCache[K comparable, V any] = cache.TTLCache[K, V]
+
+// NewTTLCache 创建过期缓存，defaultTTL为Put使用的默认过期时间，小于等于0表示永不过期
+func NewTTLCache[K comparable, V any](defaultTTL time.Duration, opts ...TTLOptions[K, V]) *TTLCache[K, V] {
+	return cache.NewTTLCache(defaultTTL, opts...)
+}
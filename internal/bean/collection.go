@@ -0,0 +1,140 @@
+package bean
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/sword-demon/vtool/internal/slice"
+)
+
+// CopySlice 将src中的每个元素复制为D类型，返回新的切片
+// S和D可以是结构体、结构体指针或键为字符串的map，所有元素共用同一份复制计划
+// nil元素对应D的零值，Options.Parallelism大于1时并行复制
+func CopySlice[S any, D any](src []S, opts ...Options) ([]D, error) {
+	if src == nil {
+		return nil, nil
+	}
+	convert, parallelism, err := newElementConverter[S, D](opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if parallelism > 1 {
+		return slice.ParallelMapErr(context.Background(), src, convert, slice.ParallelOptions{Workers: parallelism})
+	}
+	result := make([]D, len(src))
+	for i, item := range src {
+		if result[i], err = convert(item); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// CopyMap 将src中的每个值复制为D类型，返回键相同的新map
+// 所有值共用同一份复制计划，Options.Parallelism大于1时并行复制
+func CopyMap[K comparable, S any, D any](src map[K]S, opts ...Options) (map[K]D, error) {
+	if src == nil {
+		return nil, nil
+	}
+	convert, parallelism, err := newElementConverter[S, D](opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[K]D, len(src))
+	if parallelism > 1 {
+		keys := make([]K, 0, len(src))
+		values := make([]S, 0, len(src))
+		for key, value := range src {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		converted, err := slice.ParallelMapErr(context.Background(), values, convert, slice.ParallelOptions{Workers: parallelism})
+		if err != nil {
+			return nil, err
+		}
+		for i, key := range keys {
+			result[key] = converted[i]
+		}
+		return result, nil
+	}
+
+	for key, value := range src {
+		if result[key], err = convert(value); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Convert 将src复制为新的D类型值
+// src 结构体、结构体指针或键为字符串的map，D 结构体、结构体指针或键为字符串的map
+func Convert[D any](src interface{}, opts ...Options) (D, error) {
+	var dst D
+	// 允许直接传入结构体值
+	if v := reflect.ValueOf(src); v.Kind() == reflect.Struct {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		src = ptr.Interface()
+	}
+	if err := Copy(src, &dst, opts...); err != nil {
+		var zero D
+		return zero, err
+	}
+	return dst, nil
+}
+
+// newElementConverter 为S到D的元素复制加载复制计划，返回复制单个元素的函数
+func newElementConverter[S any, D any](opts []Options) (func(S) (D, error), int, error) {
+	options := defaultOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	srcType := indirectType(reflect.TypeFor[S]())
+	dstType := indirectType(reflect.TypeFor[D]())
+	if !isStructOrStringMap(srcType) || !isStructOrStringMap(dstType) {
+		return nil, 0, errors.New("source and destination must be structs or maps with string keys")
+	}
+	plan, err := loadPlan(srcType, dstType, &options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	convert := func(item S) (D, error) {
+		var dst D
+		srcVal := reflect.ValueOf(&item).Elem()
+		for srcVal.Kind() == reflect.Ptr {
+			if srcVal.IsNil() {
+				return dst, nil
+			}
+			srcVal = srcVal.Elem()
+		}
+
+		dstVal := reflect.ValueOf(&dst).Elem()
+		for dstVal.Kind() == reflect.Ptr {
+			dstVal.Set(reflect.New(dstVal.Type().Elem()))
+			dstVal = dstVal.Elem()
+		}
+		if dstVal.Kind() == reflect.Map {
+			dstVal.Set(reflect.MakeMap(dstVal.Type()))
+		}
+
+		if err := plan.execute(srcVal, dstVal, &options); err != nil {
+			var zero D
+			return zero, err
+		}
+		return dst, nil
+	}
+	return convert, options.Parallelism, nil
+}
+
+// indirectType 返回指针最终指向的类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,165 @@
+package bean
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopySlice(t *testing.T) {
+	users := []UserEntity{
+		{ID: 1, UserName: "a", Email: "a@x.com"},
+		{ID: 2, UserName: "b"},
+	}
+
+	t.Run("结构体切片", func(t *testing.T) {
+		dtos, err := CopySlice[UserEntity, UserDTO](users)
+		assert.NoError(t, err)
+		assert.Equal(t, []UserDTO{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, dtos)
+	})
+
+	t.Run("指针元素", func(t *testing.T) {
+		dtos, err := CopySlice[*UserEntity, *UserDTO]([]*UserEntity{&users[0], nil})
+		assert.NoError(t, err)
+		assert.Equal(t, []*UserDTO{{ID: 1, Name: "a"}, nil}, dtos)
+	})
+
+	t.Run("选项", func(t *testing.T) {
+		dtos, err := CopySlice[UserEntity, UserDTO](users, Options{FieldMapping: map[string]string{"Email": "Mail"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "a@x.com", dtos[0].Mail)
+	})
+
+	t.Run("结构体转map", func(t *testing.T) {
+		rows, err := CopySlice[UserEntity, map[string]interface{}](users[:1])
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{{"ID": int64(1), "Name": "a", "Email": "a@x.com"}}, rows)
+	})
+
+	t.Run("空切片", func(t *testing.T) {
+		dtos, err := CopySlice[UserEntity, UserDTO](nil)
+		assert.NoError(t, err)
+		assert.Nil(t, dtos)
+
+		dtos, err = CopySlice[UserEntity, UserDTO]([]UserEntity{})
+		assert.NoError(t, err)
+		assert.Equal(t, []UserDTO{}, dtos)
+	})
+
+	t.Run("并行复制", func(t *testing.T) {
+		src := make([]UserEntity, 1000)
+		for i := range src {
+			src[i] = UserEntity{ID: int64(i), UserName: fmt.Sprint(i)}
+		}
+
+		dtos, err := CopySlice[UserEntity, *UserDTO](src, Options{Parallelism: 4})
+		assert.NoError(t, err)
+		assert.Len(t, dtos, len(src))
+		for i, dto := range dtos {
+			assert.Equal(t, &UserDTO{ID: int64(i), Name: fmt.Sprint(i)}, dto)
+		}
+	})
+
+	t.Run("错误情况", func(t *testing.T) {
+		_, err := CopySlice[int, UserDTO]([]int{1})
+		assert.Error(t, err)
+
+		type Dest struct {
+			Phone string `copier:",must"`
+		}
+		_, err = CopySlice[UserEntity, Dest](users)
+		assert.EqualError(t, err, "field Phone is required but has no source field")
+
+		type Bad struct {
+			ID []int
+		}
+		_, err = CopySlice[UserEntity, Bad](users, Options{Parallelism: 2})
+		assert.EqualError(t, err, "error copying field ID: cannot convert from int64 to []int")
+	})
+}
+
+func TestCopyMap(t *testing.T) {
+	src := map[string]*UserEntity{
+		"a": {ID: 1, UserName: "a"},
+		"b": {ID: 2, UserName: "b"},
+		"c": nil,
+	}
+	expected := map[string]UserDTO{
+		"a": {ID: 1, Name: "a"},
+		"b": {ID: 2, Name: "b"},
+		"c": {},
+	}
+
+	t.Run("串行", func(t *testing.T) {
+		dst, err := CopyMap[string, *UserEntity, UserDTO](src)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dst)
+	})
+
+	t.Run("并行", func(t *testing.T) {
+		dst, err := CopyMap[string, *UserEntity, UserDTO](src, Options{Parallelism: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, dst)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		dst, err := CopyMap[string, UserEntity, UserDTO](nil)
+		assert.NoError(t, err)
+		assert.Nil(t, dst)
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("结构体", func(t *testing.T) {
+		dto, err := Convert[UserDTO](&UserEntity{ID: 1, UserName: "a"})
+		assert.NoError(t, err)
+		assert.Equal(t, UserDTO{ID: 1, Name: "a"}, dto)
+
+		ptr, err := Convert[*UserDTO](UserEntity{ID: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, &UserDTO{ID: 2}, ptr)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		dto, err := Convert[UserDTO](map[string]interface{}{"ID": "3", "Name": "c"})
+		assert.NoError(t, err)
+		assert.Equal(t, UserDTO{ID: 3, Name: "c"}, dto)
+
+		m, err := Convert[map[string]interface{}](&PartialStruct{Name: "d"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"Name": "d", "City": ""}, m)
+	})
+
+	t.Run("错误返回零值", func(t *testing.T) {
+		dto, err := Convert[UserDTO](&UserEntity{ID: 1}, Options{FieldMapping: map[string]string{"Phone": "Name"}})
+		assert.Error(t, err)
+		assert.Equal(t, UserDTO{}, dto)
+	})
+}
+
+func BenchmarkCopySlice(b *testing.B) {
+	src := make([]BenchSource, 1000)
+	for i := range src {
+		src[i] = *newBenchSource()
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := CopySlice[BenchSource, BenchDest](src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCopySliceParallel(b *testing.B) {
+	src := make([]BenchSource, 1000)
+	for i := range src {
+		src[i] = *newBenchSource()
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := CopySlice[BenchSource, BenchDest](src, Options{Parallelism: 4}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,9 +2,7 @@ package bean
 
 import (
 	"errors"
-	"fmt"
 	"reflect"
-	"time"
 )
 
 // Converter 类型转换函数类型
@@ -16,6 +14,16 @@ type Options struct {
 	IgnoreFields []string
 	DeepCopy     bool
 	IgnoreEmpty  bool
+	// MatchMode 源字段与目标字段名称的匹配方式，默认精确匹配
+	MatchMode MatchMode
+	// FieldMapping 显式指定源字段名到目标字段名的映射，优先于按名称匹配
+	// 键和值可以是以.分隔的嵌套字段路径，例如"Address.City": "City"
+	FieldMapping map[string]string
+	// Parallelism CopySlice和CopyMap并行复制的goroutine数量，小于2时串行复制
+	Parallelism int
+
+	// weakTyping 是否启用字符串与数字之间的弱类型转换，由compilePlan按源和目标的种类设置
+	weakTyping bool
 }
 
 // 默认选项
@@ -26,7 +34,7 @@ var defaultOptions = Options{
 }
 
 // Copy 复制结构体
-// src 源结构体指针，dst 目标结构体指针
+// src 源结构体指针，dst 目标结构体指针，任一侧也可以是键为字符串的map
 func Copy(src, dst interface{}, opts ...Options) error {
 	if src == nil || dst == nil {
 		return errors.New("source and destination cannot be nil")
@@ -44,8 +52,12 @@ func Copy(src, dst interface{}, opts ...Options) error {
 		return err
 	}
 
-	// 执行复制
-	return copyValue(srcVal, dstVal, options)
+	// 执行复制，同一对类型和选项的复制计划只编译一次
+	plan, err := loadPlan(srcVal.Type(), dstVal.Type(), &options)
+	if err != nil {
+		return err
+	}
+	return plan.execute(srcVal, dstVal, &options)
 }
 
 // CopyWithoutNil 复制结构体，跳过nil指针
@@ -64,12 +76,14 @@ func DeepCopy(src, dst interface{}) error {
 }
 
 // validateAndGetValues 验证输入并获取反射值
+// 结构体必须通过指针传入；键为字符串的map可以直接传入，目标map为nil指针时会被创建
 func validateAndGetValues(src, dst interface{}) (reflect.Value, reflect.Value, error) {
 	srcVal := reflect.ValueOf(src)
 	dstVal := reflect.ValueOf(dst)
 
 	// 检查指针
-	if srcVal.Kind() != reflect.Ptr || dstVal.Kind() != reflect.Ptr {
+	if (srcVal.Kind() != reflect.Ptr && !isStringMap(srcVal.Type())) ||
+		(dstVal.Kind() != reflect.Ptr && !isStringMap(dstVal.Type())) {
 		return reflect.Value{}, reflect.Value{}, errors.New("source and destination must be pointers")
 	}
 
@@ -88,92 +102,19 @@ func validateAndGetValues(src, dst interface{}) (reflect.Value, reflect.Value, e
 		}
 		dstVal = dstVal.Elem()
 	}
-
-	// 检查类型
-	if srcVal.Kind() != reflect.Struct || dstVal.Kind() != reflect.Struct {
-		return reflect.Value{}, reflect.Value{}, errors.New("source and destination must be structs")
-	}
-
-	return srcVal, dstVal, nil
-}
-
-// copyValue 复制值
-func copyValue(srcVal, dstVal reflect.Value, options Options) error {
-	srcType := srcVal.Type()
-
-	// 创建字段映射（字段名 -> 字段信息）
-	fieldMap := make(map[string]reflect.StructField)
-	for i := 0; i < srcType.NumField(); i++ {
-		field := srcType.Field(i)
-		// 跳过不可导出字段
-		if field.PkgPath != "" {
-			continue
-		}
-		// 跳过忽略字段
-		if contains(options.IgnoreFields, field.Name) {
-			continue
-		}
-		fieldMap[field.Name] = field
-	}
-
-	// 复制字段
-	for i := 0; i < dstVal.NumField(); i++ {
-		dstField := dstVal.Type().Field(i)
-		dstFieldValue := dstVal.Field(i)
-
-		// 跳过不可导出字段
-		if dstField.PkgPath != "" {
-			continue
-		}
-
-		// 查找对应的源字段
-		if srcField, ok := fieldMap[dstField.Name]; ok {
-			srcFieldValue := srcVal.FieldByIndex(srcField.Index)
-
-			// 检查是否可以设置
-			if !dstFieldValue.CanSet() {
-				continue
-			}
-
-			// 检查是否忽略空值
-			if options.IgnoreEmpty && isZeroValue(srcFieldValue) {
-				continue
-			}
-
-			// 执行复制
-			if err := copyField(srcFieldValue, dstFieldValue, options); err != nil {
-				return fmt.Errorf("error copying field %s: %w", dstField.Name, err)
-			}
+	if dstVal.Kind() == reflect.Map && dstVal.IsNil() {
+		if !dstVal.CanSet() {
+			return reflect.Value{}, reflect.Value{}, errors.New("destination map is nil")
 		}
+		dstVal.Set(reflect.MakeMap(dstVal.Type()))
 	}
 
-	return nil
-}
-
-// copyField 复制单个字段
-func copyField(srcVal, dstVal reflect.Value, options Options) error {
-	// 如果类型相同，直接复制
-	if srcVal.Type() == dstVal.Type() {
-		// 深度复制
-		if options.DeepCopy {
-			return deepCopyValue(srcVal, dstVal)
-		}
-		dstVal.Set(srcVal)
-		return nil
-	}
-
-	// 使用转换器
-	if options.Converter != nil {
-		converted, err := options.Converter(srcVal, dstVal.Type())
-		if err != nil {
-			return err
-		}
-		dstVal.Set(converted)
-		return nil
+	// 检查类型
+	if !isStructOrStringMap(srcVal.Type()) || !isStructOrStringMap(dstVal.Type()) {
+		return reflect.Value{}, reflect.Value{}, errors.New("source and destination must be structs or maps with string keys")
 	}
 
-	// 尝试类型转换
-	return tryConvert(srcVal, dstVal)
+	return srcVal, dstVal, nil
 }
 
 // deepCopyValue 深度复制值
@@ -226,33 +167,6 @@ func deepCopyValue(srcVal, dstVal reflect.Value) error {
 	}
 }
 
-// tryConvert 尝试类型转换
-func tryConvert(srcVal, dstVal reflect.Value) error {
-	// 数字类型转换
-	if isNumeric(srcVal.Type()) && isNumeric(dstVal.Type()) {
-		return convertNumeric(srcVal, dstVal)
-	}
-
-	// 字符串转换
-	if srcVal.Type() == reflect.TypeOf(time.Time{}) && dstVal.Type() == reflect.TypeOf("") {
-		t := srcVal.Interface().(time.Time)
-		dstVal.SetString(t.Format(time.RFC3339))
-		return nil
-	}
-
-	if srcVal.Type() == reflect.TypeOf("") && dstVal.Type() == reflect.TypeOf(time.Time{}) {
-		str := srcVal.String()
-		t, err := time.Parse(time.RFC3339, str)
-		if err != nil {
-			return err
-		}
-		dstVal.Set(reflect.ValueOf(t))
-		return nil
-	}
-
-	return fmt.Errorf("cannot convert from %s to %s", srcVal.Type(), dstVal.Type())
-}
-
 // isNumeric 检查是否为数字类型
 func isNumeric(t reflect.Type) bool {
 	switch t.Kind() {
@@ -322,7 +236,9 @@ func isZeroValue(v reflect.Value) bool {
 			}
 		}
 		return true
-	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+	case reflect.Interface:
+		return v.IsNil() || isZeroValue(v.Elem())
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.Slice:
 		return v.IsNil()
 	case reflect.String:
 		return v.Len() == 0
@@ -0,0 +1,372 @@
+package bean
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
+
+// keyPlan 结构体字段与map键之间的复制步骤
+type keyPlan struct {
+	name      string        // 结构体字段名，用于错误信息
+	key       reflect.Value // 已转换为map键类型的键
+	index     []int
+	omitEmpty bool
+	must      bool
+	copy      fieldCopier
+}
+
+// mapPlan map之间的复制步骤
+type mapPlan struct {
+	matchMode MatchMode
+	rename    map[string]string
+	ignore    map[string]struct{}
+	elem      fieldCopier
+}
+
+// StructToMap 将结构体转换为map[string]interface{}
+// 键为标签名或字段名，嵌套结构体作为值原样保存
+// 默认情况下指针、切片和map字段的值与源结构体共享内存，设置Options.DeepCopy时写入其副本
+func StructToMap(src interface{}, opts ...Options) (map[string]interface{}, error) {
+	// 允许直接传入结构体值
+	if v := reflect.ValueOf(src); v.Kind() == reflect.Struct {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		src = ptr.Interface()
+	}
+
+	result := make(map[string]interface{})
+	if err := Copy(src, result, opts...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MapToStruct 将map[string]interface{}复制到结构体
+// dst 目标结构体指针，嵌套的map会递归复制到嵌套结构体
+func MapToStruct(src map[string]interface{}, dst interface{}, opts ...Options) error {
+	return Copy(src, dst, opts...)
+}
+
+// compileToMapPlan 编译结构体到map的复制计划
+// FieldMapping的键为源字段名或点分路径，值为map的键
+func compileToMapPlan(srcType, dstType reflect.Type, options *Options) (*copyPlan, error) {
+	plan := &copyPlan{kind: structToMap}
+	add := func(field reflect.StructField, tag fieldTag, key string) {
+		plan.keys = append(plan.keys, keyPlan{
+			name:      field.Name,
+			key:       reflect.ValueOf(key).Convert(dstType.Key()),
+			index:     field.Index,
+			omitEmpty: tag.omitEmpty,
+			copy:      compileFieldCopier(field.Type, dstType.Elem(), options),
+		})
+	}
+
+	for _, src := range collectFields(srcType, options) {
+		key := src.tag.name
+		if mapped, ok := options.FieldMapping[src.field.Name]; ok {
+			key = mapped
+		}
+		add(src.field, src.tag, key)
+	}
+
+	for _, srcName := range sortedKeys(options.FieldMapping) {
+		key := options.FieldMapping[srcName]
+		if !strings.Contains(srcName, ".") {
+			if _, ok := srcType.FieldByName(srcName); !ok {
+				return nil, fmt.Errorf("field mapping %s -> %s: source field %s not found", srcName, key, srcName)
+			}
+			continue
+		}
+		field, err := resolvePath(srcType, srcName)
+		if err != nil {
+			return nil, fmt.Errorf("field mapping %s -> %s: source %w", srcName, key, err)
+		}
+		add(field, fieldTag{}, key)
+	}
+	return plan, nil
+}
+
+// compileFromMapPlan 编译map到结构体的复制计划
+// FieldMapping的键为map的键，值为目标字段名或点分路径
+func compileFromMapPlan(srcType, dstType reflect.Type, options *Options) (*copyPlan, error) {
+	plan := &copyPlan{
+		kind:    mapToStruct,
+		byKey:   make(map[string]int),
+		mapPlan: mapPlan{matchMode: options.MatchMode},
+	}
+	add := func(field reflect.StructField, tag fieldTag, key string) {
+		normalized := options.MatchMode.normalize(key)
+		if _, ok := plan.byKey[normalized]; !ok {
+			plan.byKey[normalized] = len(plan.keys)
+		}
+		plan.keys = append(plan.keys, keyPlan{
+			name:      field.Name,
+			key:       reflect.ValueOf(key).Convert(srcType.Key()),
+			index:     field.Index,
+			omitEmpty: tag.omitEmpty,
+			must:      tag.must,
+			copy:      compileFieldCopier(srcType.Elem(), field.Type, options),
+		})
+	}
+
+	reverse := make(map[string]string) // 目标字段名 -> map的键
+	for _, key := range sortedKeys(options.FieldMapping) {
+		dstName := options.FieldMapping[key]
+		if !strings.Contains(dstName, ".") {
+			if _, ok := dstType.FieldByName(dstName); !ok {
+				return nil, fmt.Errorf("field mapping %s -> %s: destination field %s not found", key, dstName, dstName)
+			}
+			reverse[dstName] = key
+			continue
+		}
+		field, err := resolvePath(dstType, dstName)
+		if err != nil {
+			return nil, fmt.Errorf("field mapping %s -> %s: destination %w", key, dstName, err)
+		}
+		add(field, fieldTag{}, key)
+	}
+
+	for _, dst := range collectFields(dstType, options) {
+		key, ok := reverse[dst.field.Name]
+		if !ok {
+			key = dst.tag.name
+			// 显式映射的键不再参与按名称匹配
+			if _, mapped := options.FieldMapping[key]; mapped {
+				continue
+			}
+		}
+		if contains(options.IgnoreFields, key) {
+			continue
+		}
+		add(dst.field, dst.tag, key)
+	}
+	return plan, nil
+}
+
+// compileMapPlan 编译map之间的复制计划，FieldMapping用于重命名键
+func compileMapPlan(srcType, dstType reflect.Type, options *Options) *copyPlan {
+	plan := &copyPlan{
+		kind: mapToMap,
+		mapPlan: mapPlan{
+			rename: make(map[string]string, len(options.FieldMapping)),
+			ignore: make(map[string]struct{}, len(options.IgnoreFields)),
+			elem:   compileFieldCopier(srcType.Elem(), dstType.Elem(), options),
+		},
+	}
+	for key, renamed := range options.FieldMapping {
+		plan.rename[key] = renamed
+	}
+	for _, key := range options.IgnoreFields {
+		plan.ignore[key] = struct{}{}
+	}
+	return plan
+}
+
+// executeToMap 按计划将结构体字段写入map
+func (p *copyPlan) executeToMap(srcVal, dstVal reflect.Value, options *Options) error {
+	elemType := dstVal.Type().Elem()
+	for i := range p.keys {
+		kp := &p.keys[i]
+		// 路径上有nil指针时源字段不存在
+		srcFieldValue, err := srcVal.FieldByIndexErr(kp.index)
+		if err != nil {
+			continue
+		}
+		if (options.IgnoreEmpty || kp.omitEmpty) && isZeroValue(srcFieldValue) {
+			continue
+		}
+
+		value := reflect.New(elemType).Elem()
+		if err := kp.copy(srcFieldValue, value, options); err != nil {
+			return fmt.Errorf("error copying field %s: %w", kp.name, err)
+		}
+		dstVal.SetMapIndex(kp.key, value)
+	}
+	return nil
+}
+
+// executeFromMap 按计划将map的值写入结构体字段
+func (p *copyPlan) executeFromMap(srcVal, dstVal reflect.Value, options *Options) error {
+	found := make([]bool, len(p.keys))
+	if p.matchMode == MatchExact {
+		for i := range p.keys {
+			value := srcVal.MapIndex(p.keys[i].key)
+			if !value.IsValid() {
+				continue
+			}
+			found[i] = true
+			if err := p.setField(&p.keys[i], value, dstVal, options); err != nil {
+				return err
+			}
+		}
+	} else {
+		// 多个键匹配同一字段时，优先使用与字段键完全一致的键，否则使用字典序最小的键，
+		// 使结果不依赖map的遍历顺序
+		chosen := make([]reflect.Value, len(p.keys))
+		iter := srcVal.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			i, ok := p.byKey[p.matchMode.normalize(key.String())]
+			if !ok {
+				continue
+			}
+			if found[i] && !preferKey(key.String(), chosen[i].String(), p.keys[i].key.String()) {
+				continue
+			}
+			found[i] = true
+			chosen[i] = key
+		}
+		for i := range p.keys {
+			if !found[i] {
+				continue
+			}
+			if err := p.setField(&p.keys[i], srcVal.MapIndex(chosen[i]), dstVal, options); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range p.keys {
+		if p.keys[i].must && !found[i] {
+			return fmt.Errorf("field %s is required but key %s is missing", p.keys[i].name, p.keys[i].key)
+		}
+	}
+	return nil
+}
+
+// preferKey 检查匹配同一字段的键candidate是否优先于current，target为字段对应的键
+func preferKey(candidate, current, target string) bool {
+	if current == target {
+		return false
+	}
+	return candidate == target || candidate < current
+}
+
+// setField 将map中的值写入结构体字段
+func (p *copyPlan) setField(kp *keyPlan, value, dstVal reflect.Value, options *Options) error {
+	if (options.IgnoreEmpty || kp.omitEmpty) && isZeroValue(value) {
+		return nil
+	}
+	dstFieldValue, ok := fieldByIndexAlloc(dstVal, kp.index)
+	if !ok {
+		return nil
+	}
+	if err := kp.copy(value, dstFieldValue, options); err != nil {
+		return fmt.Errorf("error copying field %s: %w", kp.name, err)
+	}
+	return nil
+}
+
+// executeMap 按计划复制map的键值对，目标map中已有的其他键保留
+func (p *copyPlan) executeMap(srcVal, dstVal reflect.Value, options *Options) error {
+	dstType := dstVal.Type()
+	iter := srcVal.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		if _, ok := p.ignore[key]; ok {
+			continue
+		}
+		if options.IgnoreEmpty && isZeroValue(iter.Value()) {
+			continue
+		}
+		if renamed, ok := p.rename[key]; ok {
+			key = renamed
+		}
+
+		value := reflect.New(dstType.Elem()).Elem()
+		if err := p.elem(iter.Value(), value, options); err != nil {
+			return fmt.Errorf("error copying key %s: %w", key, err)
+		}
+		dstVal.SetMapIndex(reflect.ValueOf(key).Convert(dstType.Key()), value)
+	}
+	return nil
+}
+
+// compileInterfaceCopier 按接口中值的实际类型复制，每种实际类型的复制函数只编译一次
+func compileInterfaceCopier(dstType reflect.Type, options *Options) fieldCopier {
+	nested := nestedOptions(options)
+	// map中的值按实际类型复制时沿用所在计划的弱类型转换设置
+	nested.weakTyping = options.weakTyping
+	copiers := mapx.NewConcurrentHashMap[reflect.Type, fieldCopier]()
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		if srcVal.IsNil() {
+			dstVal.SetZero()
+			return nil
+		}
+
+		elem := srcVal.Elem()
+		copier, ok := copiers.Get(elem.Type())
+		if !ok {
+			copier, _ = copiers.GetOrPut(elem.Type(), compileFieldCopier(elem.Type(), dstType, &nested))
+		}
+		return copier(elem, dstVal, options)
+	}
+}
+
+// parseNumeric 将字符串解析为数字写入dstVal，空字符串对应零值
+func parseNumeric(s string, dstVal reflect.Value) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		dstVal.SetZero()
+		return nil
+	}
+
+	bits := dstVal.Type().Bits()
+	switch dstVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		dstVal.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		dstVal.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, bits)
+		if err != nil {
+			return err
+		}
+		dstVal.SetFloat(f)
+	case reflect.Complex64, reflect.Complex128:
+		c, err := strconv.ParseComplex(s, bits)
+		if err != nil {
+			return err
+		}
+		dstVal.SetComplex(c)
+	}
+	return nil
+}
+
+// formatNumeric 将数字格式化为字符串写入dstVal
+func formatNumeric(srcVal, dstVal reflect.Value) error {
+	bits := srcVal.Type().Bits()
+	switch srcVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dstVal.SetString(strconv.FormatInt(srcVal.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		dstVal.SetString(strconv.FormatUint(srcVal.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		dstVal.SetString(strconv.FormatFloat(srcVal.Float(), 'f', -1, bits))
+	case reflect.Complex64, reflect.Complex128:
+		dstVal.SetString(strconv.FormatComplex(srcVal.Complex(), 'f', -1, bits))
+	}
+	return nil
+}
+
+// isStringMap 检查类型是否为键为字符串的map
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// isStructOrStringMap 检查类型是否为结构体或键为字符串的map
+func isStructOrStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct || isStringMap(t)
+}
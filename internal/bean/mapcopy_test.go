@@ -0,0 +1,236 @@
+package bean
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Account struct {
+	ID       int64
+	Name     string `copier:"name"`
+	Password string `copier:"-"`
+	Email    string `copier:"email,omitempty"`
+	Address  *Address
+}
+
+func TestStructToMap(t *testing.T) {
+	t.Run("按标签生成键", func(t *testing.T) {
+		src := &Account{ID: 1, Name: "john", Password: "secret", Address: &Address{City: "A"}}
+
+		m, err := StructToMap(src)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"ID":      int64(1),
+			"name":    "john",
+			"Address": &Address{City: "A"},
+		}, m)
+	})
+
+	t.Run("传入结构体值", func(t *testing.T) {
+		m, err := StructToMap(Account{ID: 2, Email: "a@b.c"})
+		assert.NoError(t, err)
+		assert.Equal(t, "a@b.c", m["email"])
+		assert.Equal(t, int64(2), m["ID"])
+	})
+
+	t.Run("选项", func(t *testing.T) {
+		src := &Account{ID: 1, Name: "john", Address: &Address{City: "A"}}
+		m, err := StructToMap(src, Options{
+			IgnoreFields: []string{"ID"},
+			IgnoreEmpty:  true,
+			FieldMapping: map[string]string{"Address.City": "city", "Name": "username"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"username": "john",
+			"Address":  &Address{City: "A"},
+			"city":     "A",
+		}, m)
+	})
+
+	t.Run("深度复制", func(t *testing.T) {
+		type Src struct {
+			Tags    []string
+			Labels  map[string]string
+			Address *Address
+		}
+		src := &Src{Tags: []string{"a"}, Labels: map[string]string{"k": "v"}, Address: &Address{City: "A"}}
+
+		shared, err := StructToMap(src)
+		assert.NoError(t, err)
+		assert.Same(t, src.Address, shared["Address"])
+
+		m, err := StructToMap(src, Options{DeepCopy: true})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"Tags":    []string{"a"},
+			"Labels":  map[string]string{"k": "v"},
+			"Address": &Address{City: "A"},
+		}, m)
+
+		// 修改源结构体不影响结果
+		src.Tags[0] = "b"
+		src.Labels["k"] = "w"
+		src.Address.City = "B"
+		assert.Equal(t, []string{"a"}, m["Tags"])
+		assert.Equal(t, map[string]string{"k": "v"}, m["Labels"])
+		assert.Equal(t, &Address{City: "A"}, m["Address"])
+	})
+
+	t.Run("复制到指定类型的map", func(t *testing.T) {
+		type Src struct {
+			Age   int
+			Score float64
+		}
+		dst := map[string]string{"Other": "keep"}
+		err := Copy(&Src{Age: 30, Score: 9.5}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"Age": "30", "Score": "9.5", "Other": "keep"}, dst)
+
+		var ptr *map[string]int
+		err = Copy(&Src{Age: 30}, &ptr)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"Age": 30, "Score": 0}, *ptr)
+	})
+}
+
+func TestMapToStruct(t *testing.T) {
+	t.Run("弱类型转换", func(t *testing.T) {
+		src := map[string]interface{}{
+			"ID":      "42",
+			"name":    "john",
+			"email":   "",
+			"Address": map[string]interface{}{"City": "Hangzhou", "Street": 7},
+			"Unknown": true,
+		}
+		dst := &Account{Email: "keep"}
+
+		err := MapToStruct(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &Account{ID: 42, Name: "john", Email: "keep", Address: &Address{City: "Hangzhou", Street: "7"}}, dst)
+	})
+
+	t.Run("JSON数字", func(t *testing.T) {
+		type Dest struct {
+			Age     int
+			Ratio   float32
+			Created time.Time
+			Tags    []string
+			Labels  []interface{}
+		}
+
+		src := map[string]interface{}{
+			"Age":     float64(30),
+			"Ratio":   "0.5",
+			"Created": "2024-01-02T03:04:05Z",
+			"Tags":    []interface{}{"a", 1},
+			"Labels":  []interface{}{"x"},
+		}
+		dst := &Dest{}
+		err := MapToStruct(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &Dest{
+			Age:     30,
+			Ratio:   0.5,
+			Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Tags:    []string{"a", "1"},
+			Labels:  []interface{}{"x"},
+		}, dst)
+	})
+
+	t.Run("匹配方式和映射", func(t *testing.T) {
+		src := map[string]interface{}{"user_name": "john", "email_addr": "a@b.c", "Address.City": "x"}
+
+		dst := &UserDTO{}
+		err := MapToStruct(src, dst, Options{
+			MatchMode:    MatchSnakeCamel,
+			FieldMapping: map[string]string{"email_addr": "Mail"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &UserDTO{Mail: "a@b.c"}, dst)
+
+		type Dest struct {
+			UserName string
+			City     string
+		}
+		dest := &Dest{}
+		err = MapToStruct(map[string]interface{}{"user_name": "john", "city": "A"}, dest, Options{MatchMode: MatchSnakeCamel})
+		assert.NoError(t, err)
+		assert.Equal(t, &Dest{UserName: "john", City: "A"}, dest)
+
+		member := &Member{}
+		err = MapToStruct(map[string]interface{}{"city": "A"}, member, Options{FieldMapping: map[string]string{"city": "Address.City"}})
+		assert.NoError(t, err)
+		assert.Equal(t, &Address{City: "A"}, member.Address)
+	})
+
+	t.Run("多个键匹配同一字段", func(t *testing.T) {
+		type Dest struct {
+			UserName string
+		}
+		for i := 0; i < 20; i++ {
+			dst := &Dest{}
+			err := MapToStruct(map[string]interface{}{"user_name": "a", "UserName": "b", "username": "c"}, dst, Options{MatchMode: MatchSnakeCamel})
+			assert.NoError(t, err)
+			assert.Equal(t, "b", dst.UserName)
+
+			dst = &Dest{}
+			err = MapToStruct(map[string]interface{}{"user_name": "a", "USERNAME": "b", "username": "c"}, dst, Options{MatchMode: MatchSnakeCamel})
+			assert.NoError(t, err)
+			assert.Equal(t, "b", dst.UserName)
+		}
+	})
+
+	t.Run("忽略字段和空值", func(t *testing.T) {
+		src := map[string]interface{}{"Name": "", "Age": 0, "Email": "a@b.c"}
+		dst := &SourceStruct{Name: "old", Age: 50, Email: "old"}
+
+		err := MapToStruct(src, dst, Options{IgnoreEmpty: true, IgnoreFields: []string{"Email"}})
+		assert.NoError(t, err)
+		assert.Equal(t, &SourceStruct{Name: "old", Age: 50, Email: "old"}, dst)
+	})
+
+	t.Run("自定义转换器", func(t *testing.T) {
+		type Dest struct {
+			Active bool
+		}
+		converter := func(srcValue reflect.Value, dstType reflect.Type) (reflect.Value, error) {
+			return reflect.ValueOf(srcValue.String() == "yes"), nil
+		}
+
+		dst := &Dest{}
+		err := MapToStruct(map[string]interface{}{"Active": "yes"}, dst, Options{Converter: converter})
+		assert.NoError(t, err)
+		assert.True(t, dst.Active)
+	})
+
+	t.Run("错误情况", func(t *testing.T) {
+		type Required struct {
+			Name string `copier:",must"`
+		}
+		err := MapToStruct(map[string]interface{}{}, &Required{})
+		assert.EqualError(t, err, "field Name is required but key Name is missing")
+
+		err = MapToStruct(map[string]interface{}{"Age": "abc"}, &SourceStruct{})
+		assert.ErrorContains(t, err, "error copying field Age")
+
+		err = Copy(map[int]interface{}{}, &SourceStruct{})
+		assert.Error(t, err)
+	})
+}
+
+func TestCopyMapToMap(t *testing.T) {
+	src := map[string]interface{}{"a": 1, "b": "2", "c": 0, "d": 4}
+	dst := map[string]int{"keep": 9}
+
+	err := Copy(src, dst, Options{
+		IgnoreEmpty:  true,
+		IgnoreFields: []string{"d"},
+		FieldMapping: map[string]string{"a": "x"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"x": 1, "b": 2, "keep": 9}, dst)
+}
@@ -0,0 +1,187 @@
+package bean
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// nestedOptions 返回嵌套结构体复制计划使用的选项
+// FieldMapping和IgnoreFields只作用于最外层结构体，嵌套字段通过点分路径映射
+func nestedOptions(options *Options) Options {
+	return Options{
+		DeepCopy:  options.DeepCopy,
+		MatchMode: options.MatchMode,
+	}
+}
+
+// compileStructCopier 复制不同类型的结构体，或在结构体与map之间复制
+// 嵌套计划在首次复制时才加载，避免自引用类型在编译时无限递归
+func compileStructCopier(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	nested := nestedOptions(options)
+	var (
+		once sync.Once
+		plan *copyPlan
+		err  error
+	)
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		once.Do(func() {
+			plan, err = loadPlan(srcType, dstType, &nested)
+		})
+		if err != nil {
+			return err
+		}
+		if dstVal.Kind() == reflect.Map && dstVal.IsNil() {
+			dstVal.Set(reflect.MakeMap(dstVal.Type()))
+		}
+		return plan.execute(srcVal, dstVal, options)
+	}
+}
+
+// compilePtrSourceCopier 复制源为指针的值，源为nil时将目标置为零值
+func compilePtrSourceCopier(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	elem := compileFieldCopier(srcType.Elem(), dstType, options)
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		if srcVal.IsNil() {
+			dstVal.SetZero()
+			return nil
+		}
+		return elem(srcVal.Elem(), dstVal, options)
+	}
+}
+
+// compilePtrDestCopier 复制到指针类型的目标，总是分配新的目标对象
+func compilePtrDestCopier(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	elem := compileFieldCopier(srcType, dstType.Elem(), options)
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		value := reflect.New(dstType.Elem())
+		if err := elem(srcVal, value.Elem(), options); err != nil {
+			return err
+		}
+		dstVal.Set(value)
+		return nil
+	}
+}
+
+// compileSliceCopier 逐个元素复制不同元素类型的切片
+func compileSliceCopier(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	elem := compileFieldCopier(srcType.Elem(), dstType.Elem(), options)
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		if srcVal.IsNil() {
+			dstVal.SetZero()
+			return nil
+		}
+
+		n := srcVal.Len()
+		result := reflect.MakeSlice(dstType, n, n)
+		for i := 0; i < n; i++ {
+			if err := elem(srcVal.Index(i), result.Index(i), options); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		dstVal.Set(result)
+		return nil
+	}
+}
+
+// compileMapCopier 逐个键值对复制不同键或值类型的map
+func compileMapCopier(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	key := compileFieldCopier(srcType.Key(), dstType.Key(), options)
+	elem := compileFieldCopier(srcType.Elem(), dstType.Elem(), options)
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		if srcVal.IsNil() {
+			dstVal.SetZero()
+			return nil
+		}
+
+		result := reflect.MakeMapWithSize(dstType, srcVal.Len())
+		iter := srcVal.MapRange()
+		for iter.Next() {
+			k := reflect.New(dstType.Key()).Elem()
+			if err := key(iter.Key(), k, options); err != nil {
+				return fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			v := reflect.New(dstType.Elem()).Elem()
+			if err := elem(iter.Value(), v, options); err != nil {
+				return fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			result.SetMapIndex(k, v)
+		}
+		dstVal.Set(result)
+		return nil
+	}
+}
+
+// resolvePath 解析以.分隔的字段路径，返回的Index为完整路径，Type为最内层字段的类型
+func resolvePath(t reflect.Type, path string) (reflect.StructField, error) {
+	var (
+		index []int
+		cur   = t
+	)
+	for _, name := range strings.Split(path, ".") {
+		for cur.Kind() == reflect.Ptr {
+			cur = cur.Elem()
+		}
+		if cur.Kind() != reflect.Struct {
+			return reflect.StructField{}, fmt.Errorf("field %s not found", path)
+		}
+		field, ok := cur.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return reflect.StructField{}, fmt.Errorf("field %s not found", path)
+		}
+		index = append(index, field.Index...)
+		cur = field.Type
+	}
+	return reflect.StructField{Name: path, Type: cur, Index: index}, nil
+}
+
+// fieldByIndexAlloc 按路径获取目标字段，路径上的nil指针会被分配
+// 指针不可设置时返回false
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	if len(index) == 1 {
+		return v.Field(index[0]), true
+	}
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isStruct 检查类型是否为结构体或结构体指针
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// hasPrefix 检查index是否以prefixes中的某个路径开头
+func hasPrefix(index []int, prefixes [][]int) bool {
+	for _, prefix := range prefixes {
+		if len(index) > len(prefix) && slices.Equal(index[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys 返回排序后的键，保证编译错误的顺序稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
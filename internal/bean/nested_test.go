@@ -0,0 +1,220 @@
+package bean
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type BaseModel struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+type Address struct {
+	City   string
+	Street string
+}
+
+type AddressDTO struct {
+	City string
+}
+
+type Member struct {
+	BaseModel
+	Name    string
+	Address *Address
+}
+
+type MemberDTO struct {
+	ID        int64
+	CreatedAt string
+	Name      string
+	City      string
+}
+
+func TestCopyEmbedded(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("嵌入字段提升", func(t *testing.T) {
+		src := &Member{BaseModel: BaseModel{ID: 1, CreatedAt: created}, Name: "john"}
+		dst := &MemberDTO{}
+
+		err := Copy(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &MemberDTO{ID: 1, CreatedAt: "2024-01-02T03:04:05Z", Name: "john"}, dst)
+	})
+
+	t.Run("复制到嵌入字段", func(t *testing.T) {
+		type Dest struct {
+			*BaseModel
+			Name string
+		}
+
+		dst := &Dest{}
+		err := Copy(&BaseModel{ID: 2, CreatedAt: created}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &BaseModel{ID: 2, CreatedAt: created}, dst.BaseModel)
+	})
+
+	t.Run("源嵌入指针为nil", func(t *testing.T) {
+		type Src struct {
+			*BaseModel
+			Name string
+		}
+
+		dst := &MemberDTO{ID: 9}
+		err := Copy(&Src{Name: "john"}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &MemberDTO{ID: 9, Name: "john"}, dst)
+	})
+
+	t.Run("外层字段覆盖提升字段", func(t *testing.T) {
+		type Src struct {
+			BaseModel
+			ID string
+		}
+		type Dest struct {
+			ID string
+		}
+
+		dst := &Dest{}
+		err := Copy(&Src{BaseModel: BaseModel{ID: 1}, ID: "outer"}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "outer", dst.ID)
+	})
+
+	t.Run("跳过嵌入字段", func(t *testing.T) {
+		type Src struct {
+			BaseModel `copier:"-"`
+			Name      string
+		}
+
+		dst := &MemberDTO{}
+		err := Copy(&Src{BaseModel: BaseModel{ID: 1}, Name: "john"}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &MemberDTO{Name: "john"}, dst)
+	})
+}
+
+func TestCopyFieldPath(t *testing.T) {
+	t.Run("展开嵌套字段", func(t *testing.T) {
+		src := &Member{Name: "john", Address: &Address{City: "Hangzhou"}}
+		dst := &MemberDTO{}
+
+		err := Copy(src, dst, Options{FieldMapping: map[string]string{"Address.City": "City"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hangzhou", dst.City)
+
+		// 路径上的指针为nil时跳过
+		dst = &MemberDTO{City: "keep"}
+		err = Copy(&Member{Name: "john"}, dst, Options{FieldMapping: map[string]string{"Address.City": "City"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "keep", dst.City)
+	})
+
+	t.Run("收拢为嵌套字段", func(t *testing.T) {
+		dst := &Member{}
+		err := Copy(&MemberDTO{Name: "john", City: "Hangzhou"}, dst, Options{
+			FieldMapping: map[string]string{"City": "Address.City"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "john", dst.Name)
+		assert.Equal(t, &Address{City: "Hangzhou"}, dst.Address)
+	})
+
+	t.Run("路径不存在", func(t *testing.T) {
+		err := Copy(&Member{}, &MemberDTO{}, Options{FieldMapping: map[string]string{"Address.Zip": "City"}})
+		assert.EqualError(t, err, "field mapping Address.Zip -> City: source field Address.Zip not found")
+
+		err = Copy(&MemberDTO{}, &Member{}, Options{FieldMapping: map[string]string{"City": "Name.City"}})
+		assert.EqualError(t, err, "field mapping City -> Name.City: destination field Name.City not found")
+	})
+}
+
+func TestCopyNested(t *testing.T) {
+	t.Run("不同类型的嵌套结构体", func(t *testing.T) {
+		type Src struct {
+			Home   Address
+			Office *Address
+		}
+		type Dest struct {
+			Home   AddressDTO
+			Office *AddressDTO
+		}
+
+		dst := &Dest{}
+		err := Copy(&Src{Home: Address{City: "A"}, Office: &Address{City: "B"}}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &Dest{Home: AddressDTO{City: "A"}, Office: &AddressDTO{City: "B"}}, dst)
+
+		dst = &Dest{Office: &AddressDTO{City: "old"}}
+		err = Copy(&Src{}, dst)
+		assert.NoError(t, err)
+		assert.Nil(t, dst.Office)
+	})
+
+	t.Run("结构体切片和map", func(t *testing.T) {
+		type Src struct {
+			Addresses []Address
+			ByName    map[string]*Address
+			Scores    []int
+		}
+		type Dest struct {
+			Addresses []*AddressDTO
+			ByName    map[string]AddressDTO
+			Scores    []float64
+		}
+
+		src := &Src{
+			Addresses: []Address{{City: "A"}, {City: "B"}},
+			ByName:    map[string]*Address{"home": {City: "C"}, "none": nil},
+			Scores:    []int{1, 2},
+		}
+		dst := &Dest{}
+		err := Copy(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &Dest{
+			Addresses: []*AddressDTO{{City: "A"}, {City: "B"}},
+			ByName:    map[string]AddressDTO{"home": {City: "C"}, "none": {}},
+			Scores:    []float64{1, 2},
+		}, dst)
+
+		dst = &Dest{Addresses: []*AddressDTO{{City: "old"}}}
+		assert.NoError(t, Copy(&Src{}, dst))
+		assert.Nil(t, dst.Addresses)
+	})
+
+	t.Run("自引用类型", func(t *testing.T) {
+		type Node struct {
+			Value    int
+			Children []*Node
+		}
+		type NodeDTO struct {
+			Value    int64
+			Children []*NodeDTO
+		}
+
+		src := &Node{Value: 1, Children: []*Node{{Value: 2}, {Value: 3, Children: []*Node{{Value: 4}}}}}
+		dst := &NodeDTO{}
+		err := Copy(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &NodeDTO{Value: 1, Children: []*NodeDTO{
+			{Value: 2},
+			{Value: 3, Children: []*NodeDTO{{Value: 4}}},
+		}}, dst)
+	})
+
+	t.Run("嵌套字段转换失败", func(t *testing.T) {
+		type Src struct {
+			Items []struct{ Value []int }
+		}
+		type Dest struct {
+			Items []struct{ Value string }
+		}
+
+		err := Copy(&Src{Items: []struct{ Value []int }{{Value: []int{1}}}}, &Dest{})
+		assert.EqualError(t, err, "error copying field Items: index 0: error copying field Value: cannot convert from []int to string")
+	})
+}
@@ -0,0 +1,429 @@
+package bean
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	stringType = reflect.TypeOf("")
+)
+
+// planKey 复制计划的缓存键
+// 只有影响计划结构的选项才参与fingerprint，Converter和IgnoreEmpty在执行时读取
+type planKey struct {
+	src         reflect.Type
+	dst         reflect.Type
+	fingerprint string
+}
+
+// plans 已编译的复制计划，按源类型、目标类型和选项缓存
+// 缓存不会淘汰，每种不同的IgnoreFields和FieldMapping组合都会新增一份计划，
+// 这两个选项应使用固定的取值，不要按请求动态生成
+var plans = mapx.NewConcurrentHashMap[planKey, *copyPlan]()
+
+// fieldCopier 复制单个字段的函数，在编译计划时按字段类型选定
+type fieldCopier func(srcVal, dstVal reflect.Value, options *Options) error
+
+// fieldPlan 单个字段的复制步骤
+type fieldPlan struct {
+	name      string // 目标字段名，用于错误信息
+	srcIndex  []int
+	dstIndex  []int
+	omitEmpty bool // 标签声明了omitempty
+	copy      fieldCopier
+}
+
+// planKind 复制计划的源和目标种类
+type planKind int
+
+const (
+	structToStruct planKind = iota
+	structToMap
+	mapToStruct
+	mapToMap
+)
+
+// copyPlan 一对类型之间的复制计划，编译后只读，可并发使用
+type copyPlan struct {
+	kind   planKind
+	fields []fieldPlan // 结构体之间的字段复制步骤
+	keys   []keyPlan   // 结构体与map之间的字段复制步骤
+	byKey  map[string]int
+	mapPlan
+}
+
+// loadPlan 获取复制计划，缓存中不存在时编译并写入缓存
+// 编译失败的计划不会被缓存
+func loadPlan(srcType, dstType reflect.Type, options *Options) (*copyPlan, error) {
+	key := planKey{src: srcType, dst: dstType, fingerprint: options.fingerprint()}
+	if plan, ok := plans.Get(key); ok {
+		return plan, nil
+	}
+
+	// 在锁外编译，并发编译同一计划时只保留先写入的一份
+	plan, err := compilePlan(srcType, dstType, options)
+	if err != nil {
+		return nil, err
+	}
+	plan, _ = plans.GetOrPut(key, plan)
+	return plan, nil
+}
+
+// structField 参与匹配的字段，包括匿名嵌入结构体提升的字段
+type structField struct {
+	field   reflect.StructField // Index为从外层结构体开始的完整路径
+	tag     fieldTag
+	matched bool
+}
+
+// collectFields 收集结构体中参与复制的字段
+// 匿名嵌入的结构体展开为其提升字段，除非通过标签显式指定了名称
+func collectFields(t reflect.Type, options *Options) []*structField {
+	var (
+		fields  []*structField
+		skipped [][]int // 被跳过的匿名字段路径，其提升字段同样跳过
+	)
+	for _, field := range reflect.VisibleFields(t) {
+		if hasPrefix(field.Index, skipped) {
+			continue
+		}
+		// 跳过不可导出字段，不可导出的匿名结构体仍会提升其可导出字段
+		if !field.IsExported() {
+			continue
+		}
+		tag := parseTag(field)
+		// 跳过忽略字段
+		if tag.skip || contains(options.IgnoreFields, field.Name) {
+			if field.Anonymous {
+				skipped = append(skipped, field.Index)
+			}
+			continue
+		}
+		if field.Anonymous && !tag.named && isStruct(field.Type) {
+			continue
+		}
+		fields = append(fields, &structField{field: field, tag: tag})
+	}
+	return fields
+}
+
+// compilePlan 按源和目标的种类编译复制计划
+// 只有源或目标为map时才启用字符串与数字之间的弱类型转换，结构体之间的复制保持严格的类型检查
+func compilePlan(srcType, dstType reflect.Type, options *Options) (*copyPlan, error) {
+	compileOptions := *options
+	compileOptions.weakTyping = srcType.Kind() == reflect.Map || dstType.Kind() == reflect.Map
+	options = &compileOptions
+
+	switch {
+	case srcType.Kind() == reflect.Struct && dstType.Kind() == reflect.Struct:
+		return compileStructPlan(srcType, dstType, options)
+	case srcType.Kind() == reflect.Struct:
+		return compileToMapPlan(srcType, dstType, options)
+	case dstType.Kind() == reflect.Struct:
+		return compileFromMapPlan(srcType, dstType, options)
+	}
+	return compileMapPlan(srcType, dstType, options), nil
+}
+
+// compileStructPlan 解析结构体字段对应关系并为每个字段选定复制函数
+// 字段优先按FieldMapping匹配，其次按标签名或字段名在MatchMode下匹配
+// FieldMapping的键和值都可以是以.分隔的嵌套字段路径，用于展开或收拢嵌套结构体
+func compileStructPlan(srcType, dstType reflect.Type, options *Options) (*copyPlan, error) {
+	var (
+		plan    = &copyPlan{}
+		sources = collectFields(srcType, options)
+		byName  = make(map[string]*structField) // 匹配键 -> 源字段
+		byField = make(map[string]*structField) // 字段名 -> 源字段
+		mapped  = make(map[string]*structField) // FieldMapping中的目标字段名 -> 源字段
+	)
+	for _, src := range sources {
+		if _, ok := byField[src.field.Name]; !ok {
+			byField[src.field.Name] = src
+		}
+		if _, ok := options.FieldMapping[src.field.Name]; ok {
+			// 显式映射的字段不再参与按名称匹配
+			continue
+		}
+		key := options.MatchMode.normalize(src.tag.name)
+		if _, ok := byName[key]; !ok {
+			byName[key] = src
+		}
+	}
+
+	for _, srcName := range sortedKeys(options.FieldMapping) {
+		dstName := options.FieldMapping[srcName]
+		src, ok := byField[srcName]
+		if !ok {
+			field, err := resolvePath(srcType, srcName)
+			if err != nil {
+				return nil, fmt.Errorf("field mapping %s -> %s: source %w", srcName, dstName, err)
+			}
+			src = &structField{field: field}
+		}
+		if !strings.Contains(dstName, ".") {
+			mapped[dstName] = src
+			continue
+		}
+
+		dstField, err := resolvePath(dstType, dstName)
+		if err != nil {
+			return nil, fmt.Errorf("field mapping %s -> %s: destination %w", srcName, dstName, err)
+		}
+		src.matched = true
+		plan.fields = append(plan.fields, newFieldPlan(src, &structField{field: dstField}, options))
+	}
+
+	for _, dst := range collectFields(dstType, options) {
+		src, ok := mapped[dst.field.Name]
+		if ok {
+			delete(mapped, dst.field.Name)
+		} else {
+			src, ok = byName[options.MatchMode.normalize(dst.tag.name)]
+		}
+		if !ok {
+			if dst.tag.must {
+				return nil, fmt.Errorf("field %s is required but has no source field", dst.field.Name)
+			}
+			continue
+		}
+
+		src.matched = true
+		plan.fields = append(plan.fields, newFieldPlan(src, dst, options))
+	}
+
+	for _, dstName := range sortedKeys(mapped) {
+		src := mapped[dstName]
+		// 目标字段被标签或IgnoreFields跳过时不算错误
+		if _, ok := dstType.FieldByName(dstName); !ok {
+			return nil, fmt.Errorf("field mapping %s -> %s: destination field %s not found", src.field.Name, dstName, dstName)
+		}
+	}
+	for _, src := range sources {
+		if src.tag.must && !src.matched {
+			return nil, fmt.Errorf("field %s is required but has no destination field", src.field.Name)
+		}
+	}
+	return plan, nil
+}
+
+// newFieldPlan 创建src到dst的字段复制步骤
+func newFieldPlan(src, dst *structField, options *Options) fieldPlan {
+	return fieldPlan{
+		name:      dst.field.Name,
+		srcIndex:  src.field.Index,
+		dstIndex:  dst.field.Index,
+		omitEmpty: src.tag.omitEmpty || dst.tag.omitEmpty,
+		copy:      compileFieldCopier(src.field.Type, dst.field.Type, options),
+	}
+}
+
+// execute 按计划将srcVal复制到dstVal
+func (p *copyPlan) execute(srcVal, dstVal reflect.Value, options *Options) error {
+	switch p.kind {
+	case structToMap:
+		return p.executeToMap(srcVal, dstVal, options)
+	case mapToStruct:
+		return p.executeFromMap(srcVal, dstVal, options)
+	case mapToMap:
+		return p.executeMap(srcVal, dstVal, options)
+	}
+
+	for i := range p.fields {
+		field := &p.fields[i]
+		// 路径上有nil指针时源字段不存在
+		srcFieldValue, err := srcVal.FieldByIndexErr(field.srcIndex)
+		if err != nil {
+			continue
+		}
+
+		// 检查是否忽略空值
+		if (options.IgnoreEmpty || field.omitEmpty) && isZeroValue(srcFieldValue) {
+			continue
+		}
+
+		dstFieldValue, ok := fieldByIndexAlloc(dstVal, field.dstIndex)
+		if !ok {
+			continue
+		}
+		if err := field.copy(srcFieldValue, dstFieldValue, options); err != nil {
+			return fmt.Errorf("error copying field %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// compileFieldCopier 根据源类型和目标类型选定字段复制函数
+// 类型相同时直接赋值或深度复制，否则优先使用自定义转换器，再使用内置转换
+func compileFieldCopier(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	// 接口类型按实际类型选择复制函数，自定义转换器接收的是实际值
+	if srcType.Kind() == reflect.Interface && srcType != dstType {
+		return compileInterfaceCopier(dstType, options)
+	}
+
+	// 如果类型相同，直接复制
+	if srcType == dstType {
+		if options.DeepCopy && needsDeepCopy(srcType) {
+			return func(srcVal, dstVal reflect.Value, _ *Options) error {
+				return deepCopyValue(srcVal, dstVal)
+			}
+		}
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			dstVal.Set(srcVal)
+			return nil
+		}
+	}
+
+	convert := compileConverter(srcType, dstType, options)
+	return func(srcVal, dstVal reflect.Value, options *Options) error {
+		// 自定义转换器优先
+		if options.Converter != nil {
+			converted, err := options.Converter(srcVal, dstVal.Type())
+			if err != nil {
+				return err
+			}
+			dstVal.Set(converted)
+			return nil
+		}
+		return convert(srcVal, dstVal, options)
+	}
+}
+
+// compileConverter 选定不同类型之间的内置转换函数
+// 不同类型的结构体、切片、map和指针会逐层递归复制
+func compileConverter(srcType, dstType reflect.Type, options *Options) fieldCopier {
+	switch {
+	// 数字类型转换
+	case isNumeric(srcType) && isNumeric(dstType):
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			return convertNumeric(srcVal, dstVal)
+		}
+
+	// 时间与字符串转换
+	case srcType == timeType && dstType == stringType:
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			t := srcVal.Interface().(time.Time)
+			dstVal.SetString(t.Format(time.RFC3339))
+			return nil
+		}
+
+	case srcType == stringType && dstType == timeType:
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			// 空字符串对应零值时间
+			if srcVal.Len() == 0 {
+				dstVal.SetZero()
+				return nil
+			}
+			t, err := time.Parse(time.RFC3339, srcVal.String())
+			if err != nil {
+				return err
+			}
+			dstVal.Set(reflect.ValueOf(t))
+			return nil
+		}
+
+	// 字符串与数字的弱类型转换，只用于与map之间的复制
+	case options.weakTyping && srcType.Kind() == reflect.String && isNumeric(dstType):
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			return parseNumeric(srcVal.String(), dstVal)
+		}
+
+	case options.weakTyping && isNumeric(srcType) && dstType.Kind() == reflect.String:
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			return formatNumeric(srcVal, dstVal)
+		}
+
+	// 复制到接口类型，例如结构体字段写入map[string]interface{}；DeepCopy时先复制引用类型的值
+	case dstType.Kind() == reflect.Interface && srcType.Implements(dstType):
+		if options.DeepCopy && needsDeepCopy(srcType) {
+			return func(srcVal, dstVal reflect.Value, _ *Options) error {
+				value := reflect.New(srcType).Elem()
+				if err := deepCopyValue(srcVal, value); err != nil {
+					return err
+				}
+				dstVal.Set(value)
+				return nil
+			}
+		}
+		return func(srcVal, dstVal reflect.Value, _ *Options) error {
+			dstVal.Set(srcVal)
+			return nil
+		}
+
+	case srcType.Kind() == reflect.Ptr:
+		return compilePtrSourceCopier(srcType, dstType, options)
+
+	case dstType.Kind() == reflect.Ptr:
+		return compilePtrDestCopier(srcType, dstType, options)
+
+	case isStructOrStringMap(srcType) && isStructOrStringMap(dstType) &&
+		(srcType.Kind() == reflect.Struct || dstType.Kind() == reflect.Struct):
+		return compileStructCopier(srcType, dstType, options)
+
+	case srcType.Kind() == reflect.Slice && dstType.Kind() == reflect.Slice:
+		return compileSliceCopier(srcType, dstType, options)
+
+	case srcType.Kind() == reflect.Map && dstType.Kind() == reflect.Map:
+		return compileMapCopier(srcType, dstType, options)
+	}
+
+	return func(srcVal, dstVal reflect.Value, _ *Options) error {
+		return fmt.Errorf("cannot convert from %s to %s", srcType, dstType)
+	}
+}
+
+// needsDeepCopy 检查类型是否包含需要深度复制的引用
+func needsDeepCopy(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Struct, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// fingerprint 返回影响计划结构的选项摘要，IgnoreFields和FieldMapping与顺序无关
+// 每个名称都经过strconv.Quote，避免名称中的分隔符造成不同选项得到相同摘要
+// 未设置IgnoreFields和FieldMapping时不会克隆和排序，摘要不产生内存分配
+func (o *Options) fingerprint() string {
+	if !o.DeepCopy && o.MatchMode == MatchExact && len(o.FieldMapping) == 0 && len(o.IgnoreFields) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	if o.DeepCopy {
+		sb.WriteString("deep;")
+	}
+	if o.MatchMode != MatchExact {
+		sb.WriteString("match=")
+		sb.WriteString(strconv.Itoa(int(o.MatchMode)))
+		sb.WriteByte(';')
+	}
+	if len(o.FieldMapping) > 0 {
+		pairs := make([]string, 0, len(o.FieldMapping))
+		for srcName, dstName := range o.FieldMapping {
+			pairs = append(pairs, strconv.Quote(srcName)+":"+strconv.Quote(dstName))
+		}
+		slices.Sort(pairs)
+		sb.WriteString("mapping=")
+		sb.WriteString(strings.Join(pairs, ","))
+		sb.WriteByte(';')
+	}
+	if len(o.IgnoreFields) > 0 {
+		ignore := make([]string, len(o.IgnoreFields))
+		for i, name := range o.IgnoreFields {
+			ignore[i] = strconv.Quote(name)
+		}
+		slices.Sort(ignore)
+		sb.WriteString("ignore=")
+		sb.WriteString(strings.Join(ignore, ","))
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
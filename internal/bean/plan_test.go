@@ -0,0 +1,193 @@
+package bean
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type BenchSource struct {
+	ID        int64
+	Name      string
+	Email     string
+	Age       int
+	Score     float64
+	Tags      []string
+	Active    bool
+	CreatedAt time.Time
+	Level     int32
+	Remark    string
+}
+
+type BenchDest struct {
+	ID        int64
+	Name      string
+	Email     string
+	Age       int64
+	Score     float32
+	Tags      []string
+	Active    bool
+	CreatedAt string
+	Level     int
+	Extra     string
+}
+
+func newBenchSource() *BenchSource {
+	return &BenchSource{
+		ID:        1,
+		Name:      "John",
+		Email:     "john@example.com",
+		Age:       30,
+		Score:     98.5,
+		Tags:      []string{"a", "b"},
+		Active:    true,
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     3,
+		Remark:    "remark",
+	}
+}
+
+func TestCopyPlan(t *testing.T) {
+	srcType := reflect.TypeOf(BenchSource{})
+	dstType := reflect.TypeOf(BenchDest{})
+
+	t.Run("计划只编译一次", func(t *testing.T) {
+		options := Options{}
+		first, _ := loadPlan(srcType, dstType, &options)
+		second, _ := loadPlan(srcType, dstType, &options)
+		assert.Same(t, first, second)
+		// Remark和Extra没有对应字段
+		assert.Len(t, first.fields, 9)
+	})
+
+	t.Run("选项不同使用不同计划", func(t *testing.T) {
+		plain, _ := loadPlan(srcType, dstType, &Options{})
+		ignored, _ := loadPlan(srcType, dstType, &Options{IgnoreFields: []string{"Name", "Age"}})
+		assert.NotSame(t, plain, ignored)
+		assert.Len(t, ignored.fields, 7)
+
+		// IgnoreFields的顺序不影响计划
+		reordered, _ := loadPlan(srcType, dstType, &Options{IgnoreFields: []string{"Age", "Name"}})
+		assert.Same(t, ignored, reordered)
+
+		// 转换器和忽略空值在执行时读取，不单独编译计划
+		withConverter, _ := loadPlan(srcType, dstType, &Options{IgnoreEmpty: true, Converter: func(v reflect.Value, _ reflect.Type) (reflect.Value, error) {
+			return v, nil
+		}})
+		assert.Same(t, plain, withConverter)
+	})
+
+	t.Run("名称包含分隔符", func(t *testing.T) {
+		src := map[string]interface{}{"a": 1, "b": 2, "a,b": 3}
+
+		d1 := map[string]interface{}{}
+		assert.NoError(t, Copy(src, d1, Options{IgnoreFields: []string{"a,b"}}))
+		assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, d1)
+
+		d2 := map[string]interface{}{}
+		assert.NoError(t, Copy(src, d2, Options{IgnoreFields: []string{"a", "b"}}))
+		assert.Equal(t, map[string]interface{}{"a,b": 3}, d2)
+
+		m1 := &Options{FieldMapping: map[string]string{"Name": "Age,Score:Level"}}
+		m2 := &Options{FieldMapping: map[string]string{"Name": "Age", "Score": "Level"}}
+		assert.NotEqual(t, m1.fingerprint(), m2.fingerprint())
+	})
+
+	t.Run("使用缓存计划复制", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			dst := &BenchDest{Extra: "keep"}
+			err := Copy(newBenchSource(), dst)
+			assert.NoError(t, err)
+			assert.Equal(t, &BenchDest{
+				ID:        1,
+				Name:      "John",
+				Email:     "john@example.com",
+				Age:       30,
+				Score:     98.5,
+				Tags:      []string{"a", "b"},
+				Active:    true,
+				CreatedAt: "2024-01-02T03:04:05Z",
+				Level:     3,
+				Extra:     "keep",
+			}, dst)
+		}
+	})
+
+	t.Run("转换失败", func(t *testing.T) {
+		type Src struct{ Value []int }
+		type Dest struct{ Value string }
+
+		err := Copy(&Src{Value: []int{1}}, &Dest{})
+		assert.EqualError(t, err, "error copying field Value: cannot convert from []int to string")
+	})
+
+	t.Run("结构体之间不做字符串与数字的弱类型转换", func(t *testing.T) {
+		type Src struct {
+			Age  string
+			Code int
+		}
+		type Dest struct {
+			Age  int
+			Code string
+		}
+
+		err := Copy(&Src{Age: "30"}, &Dest{}, Options{IgnoreFields: []string{"Code"}})
+		assert.EqualError(t, err, "error copying field Age: cannot convert from string to int")
+		err = Copy(&Src{Code: 7}, &Dest{}, Options{IgnoreFields: []string{"Age"}})
+		assert.EqualError(t, err, "error copying field Code: cannot convert from int to string")
+
+		// 与map之间的复制仍然支持弱类型转换
+		dst := &Dest{}
+		assert.NoError(t, Copy(map[string]interface{}{"Age": "30", "Code": 7}, dst))
+		assert.Equal(t, &Dest{Age: 30, Code: "7"}, dst)
+	})
+
+	t.Run("并发复制", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					dst := &BenchDest{}
+					assert.NoError(t, Copy(newBenchSource(), dst, Options{DeepCopy: true}))
+					assert.Equal(t, "John", dst.Name)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func BenchmarkCopy(b *testing.B) {
+	src := newBenchSource()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Copy(src, &BenchDest{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCopyWithoutCache 每次复制都重新编译计划，对应缓存之前的逐字段反射解析
+func BenchmarkCopyWithoutCache(b *testing.B) {
+	src := newBenchSource()
+	options := Options{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		srcVal, dstVal, err := validateAndGetValues(src, &BenchDest{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		plan, err := compilePlan(srcVal.Type(), dstVal.Type(), &options)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := plan.execute(srcVal, dstVal, &options); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
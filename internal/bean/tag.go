@@ -0,0 +1,71 @@
+package bean
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagName 字段映射使用的结构体标签
+// 格式为`copier:"name,omitempty,must"`，name为空时使用字段名，`copier:"-"`表示跳过该字段
+const tagName = "copier"
+
+// MatchMode 字段名称的匹配方式
+// 从map复制时若多个键匹配同一字段，优先使用与字段名完全一致的键，否则使用字典序最小的键
+type MatchMode int
+
+const (
+	// MatchExact 字段名称完全一致才匹配
+	MatchExact MatchMode = iota
+	// MatchIgnoreCase 忽略大小写匹配，例如Name与NAME
+	MatchIgnoreCase
+	// MatchSnakeCamel 忽略大小写和下划线匹配，例如user_name、UserName与userName
+	MatchSnakeCamel
+)
+
+// normalize 按匹配方式将字段名称转换为比较用的键
+func (m MatchMode) normalize(name string) string {
+	switch m {
+	case MatchIgnoreCase:
+		return strings.ToLower(name)
+	case MatchSnakeCamel:
+		return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	}
+	return name
+}
+
+// fieldTag 解析后的字段标签
+type fieldTag struct {
+	name      string // 用于匹配的名称，未指定时为字段名
+	named     bool   // 标签显式指定了名称
+	skip      bool
+	omitEmpty bool
+	must      bool
+}
+
+// parseTag 解析字段的copier标签
+func parseTag(field reflect.StructField) fieldTag {
+	tag := fieldTag{name: field.Name}
+	value, ok := field.Tag.Lookup(tagName)
+	if !ok {
+		return tag
+	}
+	if value == "-" {
+		tag.skip = true
+		return tag
+	}
+
+	parts := strings.Split(value, ",")
+	if parts[0] != "" {
+		tag.name = parts[0]
+		tag.named = true
+	}
+	for _, opt := range parts[1:] {
+		switch strings.TrimSpace(opt) {
+		case "omitempty":
+			tag.omitEmpty = true
+		case "must":
+			tag.must = true
+		}
+	}
+	return tag
+}
@@ -0,0 +1,160 @@
+package bean
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type UserEntity struct {
+	ID       int64
+	UserName string `copier:"Name"`
+	Password string `copier:"-"`
+	Nickname string `copier:",omitempty"`
+	Email    string
+}
+
+type UserDTO struct {
+	ID       int64
+	Name     string
+	Password string
+	Nickname string
+	Mail     string
+}
+
+func TestCopyTag(t *testing.T) {
+	t.Run("按标签名匹配并跳过字段", func(t *testing.T) {
+		src := &UserEntity{ID: 1, UserName: "john", Password: "secret", Nickname: "jj"}
+		dst := &UserDTO{}
+
+		err := Copy(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &UserDTO{ID: 1, Name: "john", Nickname: "jj"}, dst)
+	})
+
+	t.Run("omitempty保留目标值", func(t *testing.T) {
+		src := &UserEntity{ID: 2, UserName: "john"}
+		dst := &UserDTO{Name: "old", Nickname: "keep"}
+
+		err := Copy(src, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "john", dst.Name)
+		assert.Equal(t, "keep", dst.Nickname)
+	})
+
+	t.Run("目标字段标签", func(t *testing.T) {
+		type Dest struct {
+			Login string `copier:"UserName"`
+			Email string `copier:"-"`
+		}
+
+		dst := &Dest{Email: "keep"}
+		err := Copy(&PartialStruct{Name: "ignored"}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &Dest{Email: "keep"}, dst)
+
+		type Src struct {
+			UserName string
+			Email    string
+		}
+		err = Copy(&Src{UserName: "john", Email: "a@b.c"}, dst)
+		assert.NoError(t, err)
+		assert.Equal(t, &Dest{Login: "john", Email: "keep"}, dst)
+	})
+
+	t.Run("must字段缺少对应字段", func(t *testing.T) {
+		type Dest struct {
+			Name  string
+			Phone string `copier:",must"`
+		}
+		err := Copy(&PartialStruct{Name: "john"}, &Dest{})
+		assert.EqualError(t, err, "field Phone is required but has no source field")
+
+		type Src struct {
+			Name  string
+			Token string `copier:",must"`
+		}
+		err = Copy(&Src{Name: "john"}, &PartialStruct{})
+		assert.EqualError(t, err, "field Token is required but has no destination field")
+
+		type Matched struct {
+			Name string `copier:",must"`
+		}
+		dst := &Matched{}
+		assert.NoError(t, Copy(&PartialStruct{Name: "john"}, dst))
+		assert.Equal(t, "john", dst.Name)
+	})
+}
+
+func TestCopyMatchMode(t *testing.T) {
+	type Src struct {
+		UserName  string
+		UserEmail string
+	}
+	type Row struct {
+		User_Name  string
+		User_Email string
+	}
+	type Lower struct {
+		Username  string
+		Useremail string
+	}
+
+	testCases := []struct {
+		name     string
+		mode     MatchMode
+		expected *Row
+	}{
+		{name: "精确匹配", mode: MatchExact, expected: &Row{}},
+		{name: "忽略大小写", mode: MatchIgnoreCase, expected: &Row{}},
+		{name: "蛇形与驼峰", mode: MatchSnakeCamel, expected: &Row{User_Name: "john", User_Email: "a@b.c"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := &Row{}
+			err := Copy(&Src{UserName: "john", UserEmail: "a@b.c"}, dst, Options{MatchMode: tc.mode})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, dst)
+		})
+	}
+
+	t.Run("忽略大小写匹配", func(t *testing.T) {
+		dst := &Lower{}
+		err := Copy(&Src{UserName: "john"}, dst, Options{MatchMode: MatchIgnoreCase})
+		assert.NoError(t, err)
+		assert.Equal(t, "john", dst.Username)
+
+		dst = &Lower{}
+		assert.NoError(t, Copy(&Src{UserName: "john"}, dst))
+		assert.Empty(t, dst.Username)
+	})
+}
+
+func TestCopyFieldMapping(t *testing.T) {
+	t.Run("显式映射", func(t *testing.T) {
+		src := &UserEntity{ID: 1, UserName: "john", Email: "a@b.c"}
+		dst := &UserDTO{}
+
+		err := Copy(src, dst, Options{FieldMapping: map[string]string{"Email": "Mail"}})
+		assert.NoError(t, err)
+		assert.Equal(t, &UserDTO{ID: 1, Name: "john", Mail: "a@b.c"}, dst)
+	})
+
+	t.Run("映射优先于名称匹配", func(t *testing.T) {
+		dst := &DestStruct{}
+		err := Copy(&SourceStruct{Name: "john", Email: "a@b.c"}, dst, Options{
+			FieldMapping: map[string]string{"Email": "Name"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &DestStruct{Name: "a@b.c"}, dst)
+	})
+
+	t.Run("映射字段不存在", func(t *testing.T) {
+		err := Copy(&SourceStruct{}, &DestStruct{}, Options{FieldMapping: map[string]string{"Phone": "Name"}})
+		assert.EqualError(t, err, "field mapping Phone -> Name: source field Phone not found")
+
+		err = Copy(&SourceStruct{}, &DestStruct{}, Options{FieldMapping: map[string]string{"Name": "Phone"}})
+		assert.EqualError(t, err, "field mapping Name -> Phone: destination field Phone not found")
+	})
+}
@@ -0,0 +1,236 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
+
+// ARC 自适应替换缓存（Adaptive Replacement Cache），线程安全
+// t1保存只访问过一次的条目，t2保存访问过多次的条目；b1、b2分别记录最近从t1、t2淘汰的键（幽灵条目）
+// 命中幽灵条目时调整t1的目标大小p，从而在近期性和频率之间自适应，能抵抗一次性扫描对热点数据的冲刷
+type ARC[K comparable, V any] struct {
+	mu       sync.Mutex
+	t1       *mapx.LinkedMap[K, V]
+	t2       *mapx.LinkedMap[K, V]
+	b1       *mapx.LinkedMap[K, struct{}]
+	b2       *mapx.LinkedMap[K, struct{}]
+	p        int // t1的目标大小
+	capacity int
+	onEvict  func(key K, value V)
+	stats    Stats
+}
+
+// NewARC 创建容量为capacity的ARC缓存，capacity小于1时按1处理
+func NewARC[K comparable, V any](capacity int, opts ...Options[K, V]) *ARC[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	var options Options[K, V]
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	return &ARC[K, V]{
+		t1:       mapx.NewLinkedMap[K, V](),
+		t2:       mapx.NewLinkedMap[K, V](),
+		b1:       mapx.NewLinkedMap[K, struct{}](),
+		b2:       mapx.NewLinkedMap[K, struct{}](),
+		capacity: capacity,
+		onEvict:  options.OnEvict,
+	}
+}
+
+// Get 获取值，命中的条目会被移到t2的尾部
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if value, ok := c.t1.Peek(key); ok {
+		c.t1.Remove(key)
+		c.t2.Put(key, value)
+		c.stats.Hits++
+		return value, true
+	}
+	if value, ok := c.t2.Peek(key); ok {
+		c.t2.Put(key, value)
+		c.stats.Hits++
+		return value, true
+	}
+
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Peek 获取值，不改变条目位置，也不计入命中统计
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if value, ok := c.t1.Peek(key); ok {
+		return value, true
+	}
+	return c.t2.Peek(key)
+}
+
+// Put 添加或更新条目，返回是否发生了淘汰
+func (c *ARC[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+
+	// 已缓存：更新值并视为再次访问
+	if c.t1.Contains(key) {
+		c.t1.Remove(key)
+		c.t2.Put(key, value)
+		c.mu.Unlock()
+		return false
+	}
+	if c.t2.Contains(key) {
+		c.t2.Put(key, value)
+		c.mu.Unlock()
+		return false
+	}
+
+	var victim *arcVictim[K, V]
+	switch {
+	case c.b1.Contains(key):
+		// 命中b1说明t1过小，增大p
+		c.p = min(c.capacity, c.p+max(c.b2.Size()/c.b1.Size(), 1))
+		victim = c.replace(false)
+		c.b1.Remove(key)
+		c.t2.Put(key, value)
+	case c.b2.Contains(key):
+		// 命中b2说明t2过小，减小p
+		c.p = max(0, c.p-max(c.b1.Size()/c.b2.Size(), 1))
+		victim = c.replace(true)
+		c.b2.Remove(key)
+		c.t2.Put(key, value)
+	default:
+		l1 := c.t1.Size() + c.b1.Size()
+		total := l1 + c.t2.Size() + c.b2.Size()
+		if l1 >= c.capacity {
+			if c.t1.Size() < c.capacity {
+				c.b1.RemoveFirst()
+				victim = c.replace(false)
+			} else {
+				// b1为空且t1已满，直接淘汰t1中最久未访问的条目
+				k, v, _ := c.t1.RemoveFirst()
+				victim = &arcVictim[K, V]{key: k, value: v}
+			}
+		} else if total >= c.capacity {
+			if total >= 2*c.capacity {
+				c.b2.RemoveFirst()
+			}
+			victim = c.replace(false)
+		}
+		c.t1.Put(key, value)
+	}
+
+	if victim != nil {
+		c.stats.Evictions++
+	}
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if victim != nil && onEvict != nil {
+		onEvict(victim.key, victim.value)
+	}
+	return victim != nil
+}
+
+// Remove 删除条目，返回条目是否存在
+// 主动删除不会触发OnEvict，也不会留下幽灵条目
+func (c *ARC[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.t1.Contains(key) {
+		c.t1.Remove(key)
+		return true
+	}
+	if c.t2.Contains(key) {
+		c.t2.Remove(key)
+		return true
+	}
+	return false
+}
+
+// Contains 检查条目是否存在，不改变条目位置
+func (c *ARC[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t1.Contains(key) || c.t2.Contains(key)
+}
+
+// Size 返回条目数量，不包含幽灵条目
+func (c *ARC[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t1.Size() + c.t2.Size()
+}
+
+// Capacity 返回缓存容量
+func (c *ARC[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Keys 返回所有键，先t1后t2，各自从最久未访问到最近访问
+func (c *ARC[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append(c.t1.Keys(), c.t2.Keys()...)
+}
+
+// Clear 清空缓存和幽灵条目，不会触发OnEvict，统计信息保留
+func (c *ARC[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.t1.Clear()
+	c.t2.Clear()
+	c.b1.Clear()
+	c.b2.Clear()
+	c.p = 0
+}
+
+// Stats 返回命中统计
+func (c *ARC[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// ResetStats 重置命中统计
+func (c *ARC[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = Stats{}
+}
+
+// arcVictim 被淘汰的条目
+type arcVictim[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// replace 缓存已满时，根据p从t1或t2淘汰一个条目并记入对应的幽灵列表
+// inB2 表示当前访问的键命中了b2
+func (c *ARC[K, V]) replace(inB2 bool) *arcVictim[K, V] {
+	t1Size := c.t1.Size()
+	if t1Size+c.t2.Size() < c.capacity {
+		return nil
+	}
+
+	if t1Size > 0 && (t1Size > c.p || (inB2 && t1Size == c.p)) {
+		k, v, _ := c.t1.RemoveFirst()
+		c.b1.Put(k, struct{}{})
+		return &arcVictim[K, V]{key: k, value: v}
+	}
+	k, v, ok := c.t2.RemoveFirst()
+	if !ok {
+		return nil
+	}
+	c.b2.Put(k, struct{}{})
+	return &arcVictim[K, V]{key: k, value: v}
+}
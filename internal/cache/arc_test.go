@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARC(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		c := NewARC[string, int](3)
+		assert.Equal(t, 3, c.Capacity())
+
+		assert.False(t, c.Put("a", 1))
+		assert.False(t, c.Put("b", 2))
+
+		val, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+		// 访问过的a进入t2
+		assert.Equal(t, []string{"b", "a"}, c.Keys())
+
+		val, ok = c.Peek("b")
+		assert.True(t, ok)
+		assert.Equal(t, 2, val)
+
+		c.Put("b", 20)
+		val, _ = c.Get("b")
+		assert.Equal(t, 20, val)
+
+		assert.True(t, c.Remove("a"))
+		assert.False(t, c.Remove("a"))
+		assert.False(t, c.Contains("a"))
+		assert.Equal(t, 1, c.Size())
+
+		c.Clear()
+		assert.Equal(t, 0, c.Size())
+		_, ok = c.Get("b")
+		assert.False(t, ok)
+	})
+
+	t.Run("淘汰与幽灵条目", func(t *testing.T) {
+		var evicted []int
+		c := NewARC[int, int](2, Options[int, int]{
+			OnEvict: func(key int, value int) {
+				evicted = append(evicted, key)
+			},
+		})
+
+		c.Put(1, 1)
+		c.Put(2, 2)
+		assert.True(t, c.Put(3, 3))
+		assert.Equal(t, []int{1}, evicted)
+		assert.Equal(t, 2, c.Size())
+
+		// 1在b1中，再次写入直接进入t2
+		assert.True(t, c.Put(1, 1))
+		assert.Equal(t, []int{1, 2}, evicted)
+		assert.True(t, c.Contains(1))
+		assert.Equal(t, 2, c.Size())
+		assert.Equal(t, uint64(2), c.Stats().Evictions)
+	})
+
+	t.Run("容量不变式", func(t *testing.T) {
+		const capacity = 16
+		c := NewARC[int, int](capacity)
+		for i := 0; i < 5000; i++ {
+			key := (i * 7919) % 97
+			if i%3 == 0 {
+				_, _ = c.Get(key)
+			} else {
+				c.Put(key, i)
+			}
+			if i%101 == 0 {
+				c.Remove(key)
+			}
+
+			assert.LessOrEqual(t, c.t1.Size()+c.t2.Size(), capacity)
+			assert.LessOrEqual(t, c.t1.Size()+c.b1.Size(), capacity)
+			assert.LessOrEqual(t, c.t1.Size()+c.t2.Size()+c.b1.Size()+c.b2.Size(), 2*capacity)
+			assert.GreaterOrEqual(t, c.p, 0)
+			assert.LessOrEqual(t, c.p, capacity)
+		}
+	})
+
+	t.Run("并发访问", func(t *testing.T) {
+		c := NewARC[int, int](64)
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					c.Put((g*1000+i)%200, i)
+					_, _ = c.Get(i % 100)
+				}
+			}(g)
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, c.Size(), 64)
+	})
+}
@@ -0,0 +1,55 @@
+package cache
+
+// Cache 容量有限的缓存接口，LRU、LFU和ARC均实现了该接口，可按场景替换淘汰策略
+type Cache[K comparable, V any] interface {
+	// Get 获取值，会被计入命中统计并影响淘汰顺序
+	Get(key K) (V, bool)
+	// Peek 获取值，不影响淘汰顺序和命中统计
+	Peek(key K) (V, bool)
+	// Put 添加或更新条目，返回是否发生了淘汰
+	Put(key K, value V) bool
+	// Remove 删除条目，返回条目是否存在
+	Remove(key K) bool
+	// Contains 检查条目是否存在
+	Contains(key K) bool
+	// Size 返回条目数量
+	Size() int
+	// Capacity 返回缓存容量
+	Capacity() int
+	// Keys 返回所有键
+	Keys() []K
+	// Clear 清空缓存
+	Clear()
+	// Stats 返回命中统计
+	Stats() Stats
+	// ResetStats 重置命中统计
+	ResetStats()
+}
+
+var (
+	_ Cache[string, int] = (*LRU[string, int])(nil)
+	_ Cache[string, int] = (*LFU[string, int])(nil)
+	_ Cache[string, int] = (*ARC[string, int])(nil)
+)
+
+// Options 缓存选项
+type Options[K comparable, V any] struct {
+	// OnEvict 条目因容量不足被淘汰时调用，在锁外执行
+	OnEvict func(key K, value V)
+}
+
+// Stats 缓存命中统计
+type Stats struct {
+	Hits      uint64 // 命中次数
+	Misses    uint64 // 未命中次数
+	Evictions uint64 // 淘汰次数
+}
+
+// HitRate 返回命中率，没有访问记录时返回0
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runWorkload 在缓存上执行访问序列，未命中时写入，返回命中率
+func runWorkload(c Cache[int, int], keys []int) float64 {
+	c.ResetStats()
+	for _, key := range keys {
+		if _, ok := c.Get(key); !ok {
+			c.Put(key, key)
+		}
+	}
+	return c.Stats().HitRate()
+}
+
+func TestCache(t *testing.T) {
+	t.Run("统一接口", func(t *testing.T) {
+		caches := map[string]Cache[int, int]{
+			"LRU": NewLRU[int, int](2),
+			"LFU": NewLFU[int, int](2),
+			"ARC": NewARC[int, int](2),
+		}
+
+		for name, c := range caches {
+			t.Run(name, func(t *testing.T) {
+				assert.False(t, c.Put(1, 1))
+				assert.False(t, c.Put(2, 2))
+				assert.True(t, c.Put(3, 3))
+				assert.Equal(t, 2, c.Size())
+				assert.Len(t, c.Keys(), 2)
+
+				_, ok := c.Get(3)
+				assert.True(t, ok)
+				assert.Equal(t, Stats{Hits: 1, Evictions: 1}, c.Stats())
+			})
+		}
+	})
+
+	t.Run("扫描负载下的命中率", func(t *testing.T) {
+		// 热点数据反复访问，中间穿插一次性的大范围扫描
+		var keys []int
+		scan := 1000
+		for round := 0; round < 20; round++ {
+			for i := 0; i < 5; i++ {
+				for hot := 0; hot < 50; hot++ {
+					keys = append(keys, hot)
+				}
+			}
+			for i := 0; i < 200; i++ {
+				keys = append(keys, scan)
+				scan++
+			}
+		}
+
+		lru := runWorkload(NewLRU[int, int](100), keys)
+		lfu := runWorkload(NewLFU[int, int](100), keys)
+		arc := runWorkload(NewARC[int, int](100), keys)
+
+		// 扫描会冲掉LRU中的热点数据，LFU和ARC能保留热点
+		assert.Greater(t, lfu, lru)
+		assert.Greater(t, arc, lru)
+	})
+}
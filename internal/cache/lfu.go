@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
+
+// LFU 最不经常使用缓存 - 基于频率桶实现，所有操作均为O(1)，线程安全
+// 每个访问频率对应一个LinkedMap，同频率的条目按最近访问排序；超出容量时淘汰最低频率中最久未访问的条目
+type LFU[K comparable, V any] struct {
+	mu       sync.Mutex
+	freqs    map[K]int                     // 键到访问频率
+	buckets  map[int]*mapx.LinkedMap[K, V] // 访问频率到条目
+	minFreq  int
+	capacity int
+	onEvict  func(key K, value V)
+	stats    Stats
+}
+
+// NewLFU 创建容量为capacity的LFU缓存，capacity小于1时按1处理
+func NewLFU[K comparable, V any](capacity int, opts ...Options[K, V]) *LFU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	var options Options[K, V]
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	return &LFU[K, V]{
+		freqs:    make(map[K]int),
+		buckets:  make(map[int]*mapx.LinkedMap[K, V]),
+		capacity: capacity,
+		onEvict:  options.OnEvict,
+	}
+}
+
+// Get 获取值并增加该条目的访问频率
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	freq, ok := c.freqs[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+
+	c.stats.Hits++
+	value, _ := c.buckets[freq].Peek(key)
+	c.touch(key, value, freq)
+	return value, true
+}
+
+// Peek 获取值，不改变访问频率，也不计入命中统计
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	freq, ok := c.freqs[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return c.buckets[freq].Peek(key)
+}
+
+// Put 添加或更新条目，更新已有条目会增加其访问频率
+// 超出容量时淘汰访问频率最低的条目，返回是否发生了淘汰
+func (c *LFU[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+
+	if freq, ok := c.freqs[key]; ok {
+		c.touch(key, value, freq)
+		c.mu.Unlock()
+		return false
+	}
+
+	var (
+		evicted      bool
+		evictedKey   K
+		evictedValue V
+	)
+	if len(c.freqs) >= c.capacity {
+		evictedKey, evictedValue, evicted = c.evict()
+	}
+
+	c.freqs[key] = 1
+	c.bucket(1).Put(key, value)
+	c.minFreq = 1
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if evicted && onEvict != nil {
+		onEvict(evictedKey, evictedValue)
+	}
+	return evicted
+}
+
+// Remove 删除条目，返回条目是否存在
+// 主动删除不会触发OnEvict
+func (c *LFU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	freq, ok := c.freqs[key]
+	if !ok {
+		return false
+	}
+	delete(c.freqs, key)
+	c.removeFromBucket(key, freq)
+	return true
+}
+
+// Contains 检查条目是否存在，不改变访问频率
+func (c *LFU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.freqs[key]
+	return ok
+}
+
+// Frequency 返回条目的访问频率，不存在时返回0
+func (c *LFU[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.freqs[key]
+}
+
+// Size 返回条目数量
+func (c *LFU[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.freqs)
+}
+
+// Capacity 返回缓存容量
+func (c *LFU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Keys 返回所有键，顺序不确定
+func (c *LFU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, len(c.freqs))
+	for key := range c.freqs {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Clear 清空缓存，不会触发OnEvict，统计信息保留
+func (c *LFU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.freqs)
+	clear(c.buckets)
+	c.minFreq = 0
+}
+
+// Stats 返回命中统计
+func (c *LFU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// ResetStats 重置命中统计
+func (c *LFU[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = Stats{}
+}
+
+// touch 将条目从freq频率桶移到freq+1频率桶
+func (c *LFU[K, V]) touch(key K, value V, freq int) {
+	c.removeFromBucket(key, freq)
+	if c.minFreq == freq && c.buckets[freq] == nil {
+		c.minFreq++
+	}
+	c.freqs[key] = freq + 1
+	c.bucket(freq+1).Put(key, value)
+}
+
+// evict 淘汰最低频率中最久未访问的条目
+func (c *LFU[K, V]) evict() (K, V, bool) {
+	b := c.buckets[c.minFreq]
+	if b == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+
+	key, value, _ := b.RemoveFirst()
+	if b.IsEmpty() {
+		delete(c.buckets, c.minFreq)
+	}
+	delete(c.freqs, key)
+	c.stats.Evictions++
+	return key, value, true
+}
+
+// bucket 返回频率桶，不存在时创建
+func (c *LFU[K, V]) bucket(freq int) *mapx.LinkedMap[K, V] {
+	b, ok := c.buckets[freq]
+	if !ok {
+		b = mapx.NewLinkedMap[K, V]()
+		c.buckets[freq] = b
+	}
+	return b
+}
+
+// removeFromBucket 从频率桶中删除键，桶为空时删除该桶
+func (c *LFU[K, V]) removeFromBucket(key K, freq int) {
+	b := c.buckets[freq]
+	b.Remove(key)
+	if b.IsEmpty() {
+		delete(c.buckets, freq)
+	}
+}
@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFU(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		c := NewLFU[string, int](3)
+		assert.Equal(t, 3, c.Capacity())
+
+		assert.False(t, c.Put("a", 1))
+		assert.False(t, c.Put("b", 2))
+
+		val, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+		assert.Equal(t, 2, c.Frequency("a"))
+		assert.Equal(t, 1, c.Frequency("b"))
+		assert.Equal(t, 0, c.Frequency("x"))
+
+		val, ok = c.Peek("b")
+		assert.True(t, ok)
+		assert.Equal(t, 2, val)
+		assert.Equal(t, 1, c.Frequency("b"))
+
+		assert.ElementsMatch(t, []string{"a", "b"}, c.Keys())
+		assert.True(t, c.Remove("a"))
+		assert.False(t, c.Remove("a"))
+		assert.False(t, c.Contains("a"))
+		assert.Equal(t, 1, c.Size())
+
+		c.Clear()
+		assert.Equal(t, 0, c.Size())
+	})
+
+	t.Run("淘汰访问频率最低的条目", func(t *testing.T) {
+		var evicted []string
+		c := NewLFU[string, int](2, Options[string, int]{
+			OnEvict: func(key string, value int) {
+				evicted = append(evicted, key)
+			},
+		})
+
+		c.Put("a", 1)
+		c.Put("b", 2)
+		_, _ = c.Get("a")
+		_, _ = c.Get("a")
+		_, _ = c.Get("b")
+
+		assert.True(t, c.Put("c", 3))
+		assert.Equal(t, []string{"b"}, evicted)
+
+		// 新条目频率最低，再次插入时被淘汰
+		assert.True(t, c.Put("d", 4))
+		assert.Equal(t, []string{"b", "c"}, evicted)
+		assert.True(t, c.Contains("a"))
+	})
+
+	t.Run("同频率淘汰最久未访问的条目", func(t *testing.T) {
+		c := NewLFU[string, int](3)
+		c.Put("a", 1)
+		c.Put("b", 2)
+		c.Put("c", 3)
+		_, _ = c.Get("a")
+		_, _ = c.Get("b")
+
+		// a、b频率均为2，c频率为1
+		c.Put("d", 4)
+		assert.False(t, c.Contains("c"))
+
+		// 更新已有条目会增加频率
+		c.Put("d", 40)
+		_, _ = c.Get("d")
+		c.Put("e", 5)
+		assert.False(t, c.Contains("a"))
+		assert.ElementsMatch(t, []string{"b", "d", "e"}, c.Keys())
+
+		val, _ := c.Peek("d")
+		assert.Equal(t, 40, val)
+	})
+
+	t.Run("删除后继续淘汰", func(t *testing.T) {
+		c := NewLFU[int, int](2)
+		c.Put(1, 1)
+		c.Put(2, 2)
+		_, _ = c.Get(2)
+		c.Remove(1)
+
+		c.Put(3, 3)
+		c.Put(4, 4)
+		assert.ElementsMatch(t, []int{2, 4}, c.Keys())
+		assert.Equal(t, uint64(1), c.Stats().Evictions)
+	})
+
+	t.Run("并发访问", func(t *testing.T) {
+		c := NewLFU[int, int](64)
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					c.Put((g*1000+i)%200, i)
+					_, _ = c.Get(i % 100)
+				}
+			}(g)
+		}
+		wg.Wait()
+		assert.Equal(t, 64, c.Size())
+	})
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
+
+// LRU 最近最少使用缓存 - 基于访问顺序的LinkedMap实现，线程安全
+// 链表头部为最久未访问的条目，超出容量时从头部淘汰
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	items    *mapx.LinkedMap[K, V]
+	capacity int
+	onEvict  func(key K, value V)
+	stats    Stats
+}
+
+// NewLRU 创建容量为capacity的LRU缓存，capacity小于1时按1处理
+func NewLRU[K comparable, V any](capacity int, opts ...Options[K, V]) *LRU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	var options Options[K, V]
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	return &LRU[K, V]{
+		items:    mapx.NewAccessOrderLinkedMap[K, V](),
+		capacity: capacity,
+		onEvict:  options.OnEvict,
+	}
+}
+
+// Get 获取值并将该条目标记为最近使用
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	val, ok := c.items.Get(key)
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return val, ok
+}
+
+// Peek 获取值，不改变条目顺序，也不计入命中统计
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.Peek(key)
+}
+
+// Put 添加或更新条目，超出容量时淘汰最久未使用的条目
+// 返回是否发生了淘汰
+func (c *LRU[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+	c.items.Put(key, value)
+
+	if c.items.Size() <= c.capacity {
+		c.mu.Unlock()
+		return false
+	}
+
+	evictedKey, evictedValue, _ := c.items.RemoveFirst()
+	c.stats.Evictions++
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if onEvict != nil {
+		onEvict(evictedKey, evictedValue)
+	}
+	return true
+}
+
+// Remove 删除条目，返回条目是否存在
+// 主动删除不会触发OnEvict
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.items.Contains(key) {
+		return false
+	}
+	c.items.Remove(key)
+	return true
+}
+
+// Contains 检查条目是否存在，不改变条目顺序
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.Contains(key)
+}
+
+// Size 返回条目数量
+func (c *LRU[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.Size()
+}
+
+// Capacity 返回缓存容量
+func (c *LRU[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Keys 返回所有键，从最久未使用到最近使用
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.items.Keys()
+}
+
+// Clear 清空缓存，不会触发OnEvict，统计信息保留
+func (c *LRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items.Clear()
+}
+
+// Stats 返回命中统计
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// ResetStats 重置命中统计
+func (c *LRU[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = Stats{}
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		c := NewLRU[string, int](3)
+		assert.Equal(t, 3, c.Capacity())
+
+		assert.False(t, c.Put("a", 1))
+		assert.False(t, c.Put("b", 2))
+
+		val, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+		assert.True(t, c.Contains("b"))
+		assert.Equal(t, 2, c.Size())
+
+		assert.True(t, c.Remove("a"))
+		assert.False(t, c.Remove("a"))
+		assert.False(t, c.Contains("a"))
+
+		c.Clear()
+		assert.Equal(t, 0, c.Size())
+	})
+
+	t.Run("淘汰最久未使用的条目", func(t *testing.T) {
+		var evicted []string
+		c := NewLRU[string, int](2, Options[string, int]{
+			OnEvict: func(key string, value int) {
+				evicted = append(evicted, key)
+			},
+		})
+
+		c.Put("a", 1)
+		c.Put("b", 2)
+		// 访问a后b成为最久未使用
+		_, _ = c.Get("a")
+		assert.True(t, c.Put("c", 3))
+
+		assert.Equal(t, []string{"b"}, evicted)
+		assert.Equal(t, []string{"a", "c"}, c.Keys())
+
+		// 更新已有条目也会标记为最近使用
+		c.Put("a", 10)
+		c.Put("d", 4)
+		assert.Equal(t, []string{"b", "c"}, evicted)
+		assert.Equal(t, []string{"a", "d"}, c.Keys())
+	})
+
+	t.Run("Peek不改变顺序", func(t *testing.T) {
+		c := NewLRU[string, int](2)
+		c.Put("a", 1)
+		c.Put("b", 2)
+
+		val, ok := c.Peek("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+
+		c.Put("c", 3)
+		assert.False(t, c.Contains("a"))
+		assert.Equal(t, Stats{Evictions: 1}, c.Stats())
+	})
+
+	t.Run("命中统计", func(t *testing.T) {
+		c := NewLRU[int, int](1)
+		assert.Equal(t, 0.0, c.Stats().HitRate())
+
+		c.Put(1, 1)
+		_, _ = c.Get(1)
+		_, _ = c.Get(1)
+		_, _ = c.Get(1)
+		_, _ = c.Get(2)
+		c.Put(2, 2)
+
+		stats := c.Stats()
+		assert.Equal(t, uint64(3), stats.Hits)
+		assert.Equal(t, uint64(1), stats.Misses)
+		assert.Equal(t, uint64(1), stats.Evictions)
+		assert.Equal(t, 0.75, stats.HitRate())
+
+		c.ResetStats()
+		assert.Equal(t, Stats{}, c.Stats())
+	})
+
+	t.Run("无效容量", func(t *testing.T) {
+		c := NewLRU[int, int](0)
+		assert.Equal(t, 1, c.Capacity())
+		c.Put(1, 1)
+		c.Put(2, 2)
+		assert.Equal(t, []int{2}, c.Keys())
+	})
+
+	t.Run("并发访问", func(t *testing.T) {
+		c := NewLRU[int, int](100)
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					c.Put(g*1000+i, i)
+					_, _ = c.Get(i)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 100, c.Size())
+		stats := c.Stats()
+		assert.Equal(t, uint64(8000), stats.Hits+stats.Misses)
+		assert.Equal(t, uint64(7900), stats.Evictions)
+	})
+}
@@ -0,0 +1,286 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sword-demon/vtool/internal/queue"
+)
+
+// TTLOptions 过期缓存选项
+type TTLOptions[K comparable, V any] struct {
+	// Clock 时钟，默认为系统时钟，测试中可注入假时钟
+	Clock queue.Clock
+	// CleanupInterval 后台清理过期条目的间隔，小于等于0时不启动后台协程，只在读取时惰性过期
+	CleanupInterval time.Duration
+	// Sliding 滑动过期模式，每次命中都会将过期时间顺延一个TTL
+	Sliding bool
+	// OnEvict 条目过期被移除时调用，在锁外执行
+	OnEvict func(key K, value V)
+}
+
+// ttlEntry 过期缓存中的条目
+type ttlEntry[K comparable, V any] struct {
+	value    V
+	ttl      time.Duration
+	expireAt time.Time
+	key      K
+	handle   *queue.FuncHandle[*ttlEntry[K, V]] // 过期堆中的句柄，永不过期的条目为nil
+}
+
+// TTLCache 按时间过期的缓存，线程安全
+// 条目按到期时刻保存在索引堆中，清理时只需从堆顶弹出已过期的条目
+type TTLCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	items      map[K]*ttlEntry[K, V]
+	expiry     *queue.IndexedPriorityQueueFunc[*ttlEntry[K, V]]
+	defaultTTL time.Duration
+	clock      queue.Clock
+	sliding    bool
+	onEvict    func(key K, value V)
+	stats      Stats
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewTTLCache 创建过期缓存，defaultTTL为Put使用的默认过期时间，小于等于0表示永不过期
+// 设置了CleanupInterval时需要调用Close停止后台清理协程
+func NewTTLCache[K comparable, V any](defaultTTL time.Duration, opts ...TTLOptions[K, V]) *TTLCache[K, V] {
+	var options TTLOptions[K, V]
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Clock == nil {
+		options.Clock = queue.SystemClock()
+	}
+
+	c := &TTLCache[K, V]{
+		items:      make(map[K]*ttlEntry[K, V]),
+		expiry:     queue.NewIndexedPriorityQueueFunc(compareExpireAt[K, V]),
+		defaultTTL: defaultTTL,
+		clock:      options.Clock,
+		sliding:    options.Sliding,
+		onEvict:    options.OnEvict,
+		done:       make(chan struct{}),
+	}
+
+	if options.CleanupInterval > 0 {
+		c.wg.Add(1)
+		go c.janitor(options.CleanupInterval)
+	}
+	return c
+}
+
+// Put 使用默认过期时间添加或更新条目
+func (c *TTLCache[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.defaultTTL)
+}
+
+// PutWithTTL 使用指定的过期时间添加或更新条目，ttl小于等于0表示永不过期
+func (c *TTLCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		e = &ttlEntry[K, V]{key: key}
+		c.items[key] = e
+	}
+	e.value = value
+	e.ttl = ttl
+	c.schedule(e)
+}
+
+// Get 获取值，已过期的条目会被立即移除并视为未命中
+// 滑动过期模式下命中会顺延过期时间
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		c.mu.Unlock()
+		var zero V
+		return zero, false
+	}
+
+	if c.expired(e, c.clock.Now()) {
+		c.removeEntry(key, e)
+		c.stats.Misses++
+		c.stats.Evictions++
+		onEvict := c.onEvict
+		c.mu.Unlock()
+
+		if onEvict != nil {
+			onEvict(key, e.value)
+		}
+		var zero V
+		return zero, false
+	}
+
+	if c.sliding {
+		c.schedule(e)
+	}
+	c.stats.Hits++
+	value := e.value
+	c.mu.Unlock()
+	return value, true
+}
+
+// Remove 删除条目，返回条目是否存在
+// 主动删除不会触发OnEvict
+func (c *TTLCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.removeEntry(key, e)
+	return true
+}
+
+// Contains 检查未过期的条目是否存在，不改变过期时间
+func (c *TTLCache[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	return ok && !c.expired(e, c.clock.Now())
+}
+
+// TTL 返回条目的剩余存活时间，永不过期的条目返回0
+func (c *TTLCache[K, V]) TTL(key K) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	if e.handle == nil {
+		return 0, true
+	}
+	now := c.clock.Now()
+	if c.expired(e, now) {
+		return 0, false
+	}
+	return e.expireAt.Sub(now), true
+}
+
+// Size 返回条目数量，可能包含已过期但尚未清理的条目
+func (c *TTLCache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Clear 清空缓存，不会触发OnEvict，统计信息保留
+func (c *TTLCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	c.expiry.Clear()
+}
+
+// DeleteExpired 移除所有已过期的条目，返回移除的数量
+func (c *TTLCache[K, V]) DeleteExpired() int {
+	type evicted struct {
+		key   K
+		value V
+	}
+
+	c.mu.Lock()
+	now := c.clock.Now()
+	var removed []evicted
+	for {
+		e, err := c.expiry.Peek()
+		if err != nil || now.Before(e.expireAt) {
+			break
+		}
+		c.removeEntry(e.key, e)
+		removed = append(removed, evicted{key: e.key, value: e.value})
+	}
+	c.stats.Evictions += uint64(len(removed))
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if onEvict != nil {
+		for _, r := range removed {
+			onEvict(r.key, r.value)
+		}
+	}
+	return len(removed)
+}
+
+// Stats 返回命中统计，Evictions为过期移除的次数
+func (c *TTLCache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Close 停止后台清理协程并等待其退出，可重复调用
+// 关闭后缓存仍然可用，过期条目只在读取或调用DeleteExpired时移除
+func (c *TTLCache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	c.wg.Wait()
+}
+
+// janitor 后台定期清理过期条目
+func (c *TTLCache[K, V]) janitor(interval time.Duration) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.clock.After(interval):
+			c.DeleteExpired()
+		}
+	}
+}
+
+// compareExpireAt 按到期时刻比较条目，先到期的优先
+func compareExpireAt[K comparable, V any](a, b *ttlEntry[K, V]) int {
+	return a.expireAt.Compare(b.expireAt)
+}
+
+// schedule 根据条目的ttl重新计算过期时间并更新过期堆，调用方需持有锁
+func (c *TTLCache[K, V]) schedule(e *ttlEntry[K, V]) {
+	if e.ttl <= 0 {
+		if e.handle != nil {
+			_ = c.expiry.Remove(e.handle)
+			e.handle = nil
+		}
+		e.expireAt = time.Time{}
+		return
+	}
+
+	e.expireAt = c.clock.Now().Add(e.ttl)
+	if e.handle != nil {
+		_ = c.expiry.Update(e.handle, e)
+	} else {
+		e.handle = c.expiry.Enqueue(e)
+	}
+}
+
+// expired 判断条目在now时刻是否已过期
+func (c *TTLCache[K, V]) expired(e *ttlEntry[K, V], now time.Time) bool {
+	return e.handle != nil && !now.Before(e.expireAt)
+}
+
+// removeEntry 从映射和过期堆中移除条目，调用方需持有锁
+func (c *TTLCache[K, V]) removeEntry(key K, e *ttlEntry[K, V]) {
+	if e.handle != nil {
+		_ = c.expiry.Remove(e.handle)
+		e.handle = nil
+	}
+	delete(c.items, key)
+}
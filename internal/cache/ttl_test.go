@@ -0,0 +1,273 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.at.After(c.now) {
+			w.ch <- c.now
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+}
+
+func (c *fakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+func TestTTLCache(t *testing.T) {
+	t.Run("读取时惰性过期", func(t *testing.T) {
+		clock := newFakeClock()
+		var evicted []string
+		c := NewTTLCache[string, int](time.Minute, TTLOptions[string, int]{
+			Clock: clock,
+			OnEvict: func(key string, value int) {
+				evicted = append(evicted, key)
+			},
+		})
+		defer c.Close()
+
+		c.Put("a", 1)
+		c.PutWithTTL("b", 2, 2*time.Minute)
+		c.PutWithTTL("forever", 3, 0)
+
+		val, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+
+		remaining, ok := c.TTL("b")
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Minute, remaining)
+
+		clock.Advance(time.Minute)
+		assert.False(t, c.Contains("a"))
+		_, ok = c.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, []string{"a"}, evicted)
+
+		clock.Advance(time.Hour)
+		_, ok = c.Get("b")
+		assert.False(t, ok)
+		val, ok = c.Get("forever")
+		assert.True(t, ok)
+		assert.Equal(t, 3, val)
+
+		remaining, ok = c.TTL("forever")
+		assert.True(t, ok)
+		assert.Equal(t, time.Duration(0), remaining)
+
+		stats := c.Stats()
+		assert.Equal(t, uint64(2), stats.Hits)
+		assert.Equal(t, uint64(2), stats.Misses)
+		assert.Equal(t, uint64(2), stats.Evictions)
+	})
+
+	t.Run("更新条目会重置过期时间", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewTTLCache[string, int](time.Minute, TTLOptions[string, int]{Clock: clock})
+
+		c.Put("a", 1)
+		clock.Advance(50 * time.Second)
+		c.Put("a", 2)
+		clock.Advance(50 * time.Second)
+
+		val, ok := c.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, val)
+
+		// 改为永不过期
+		c.PutWithTTL("a", 3, 0)
+		clock.Advance(time.Hour)
+		assert.True(t, c.Contains("a"))
+		assert.Equal(t, 0, c.DeleteExpired())
+	})
+
+	t.Run("DeleteExpired按到期时间清理", func(t *testing.T) {
+		clock := newFakeClock()
+		var evicted []string
+		c := NewTTLCache[string, int](0, TTLOptions[string, int]{
+			Clock: clock,
+			OnEvict: func(key string, value int) {
+				evicted = append(evicted, key)
+			},
+		})
+
+		c.PutWithTTL("c", 3, 3*time.Second)
+		c.PutWithTTL("a", 1, time.Second)
+		c.PutWithTTL("b", 2, 2*time.Second)
+		c.Put("d", 4)
+
+		clock.Advance(2 * time.Second)
+		assert.Equal(t, 2, c.DeleteExpired())
+		assert.Equal(t, []string{"a", "b"}, evicted)
+		assert.Equal(t, 2, c.Size())
+
+		assert.True(t, c.Remove("c"))
+		assert.False(t, c.Remove("c"))
+		clock.Advance(time.Hour)
+		assert.Equal(t, 0, c.DeleteExpired())
+		assert.Equal(t, 1, c.Size())
+	})
+
+	t.Run("更新过期时间后按新的顺序清理", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewTTLCache[int, int](0, TTLOptions[int, int]{Clock: clock})
+		for i := 1; i <= 20; i++ {
+			c.PutWithTTL(i, i, time.Duration(i)*time.Second)
+		}
+		// 缩短、延长和取消部分条目的过期时间
+		c.PutWithTTL(20, 20, time.Second)
+		c.PutWithTTL(1, 1, 100*time.Second)
+		c.PutWithTTL(2, 2, 0)
+		// 超过100年的过期时间，纳秒时间戳超出32位int的范围
+		c.PutWithTTL(3, 3, 200*365*24*time.Hour)
+
+		clock.Advance(10 * time.Second)
+		assert.Equal(t, 8, c.DeleteExpired())
+		assert.Equal(t, 12, c.Size())
+		assert.True(t, c.Contains(1))
+		assert.False(t, c.Contains(20))
+
+		clock.Advance(time.Hour)
+		assert.Equal(t, 10, c.DeleteExpired())
+		assert.Equal(t, 2, c.Size())
+		assert.True(t, c.Contains(2))
+		ttl, ok := c.TTL(3)
+		assert.True(t, ok)
+		assert.Greater(t, ttl, 100*365*24*time.Hour)
+	})
+
+	t.Run("滑动过期", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewTTLCache[string, int](time.Minute, TTLOptions[string, int]{
+			Clock:   clock,
+			Sliding: true,
+		})
+
+		c.Put("a", 1)
+		for i := 0; i < 5; i++ {
+			clock.Advance(50 * time.Second)
+			_, ok := c.Get("a")
+			assert.True(t, ok)
+		}
+
+		// Contains不会顺延过期时间
+		clock.Advance(50 * time.Second)
+		assert.True(t, c.Contains("a"))
+		clock.Advance(10 * time.Second)
+		_, ok := c.Get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("后台清理", func(t *testing.T) {
+		clock := newFakeClock()
+		evicted := make(chan string, 1)
+		c := NewTTLCache[string, int](time.Second, TTLOptions[string, int]{
+			Clock:           clock,
+			CleanupInterval: 10 * time.Second,
+			OnEvict: func(key string, value int) {
+				evicted <- key
+			},
+		})
+
+		c.Put("a", 1)
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		clock.Advance(10 * time.Second)
+
+		select {
+		case key := <-evicted:
+			assert.Equal(t, "a", key)
+		case <-time.After(time.Second):
+			t.Fatal("后台协程未清理过期条目")
+		}
+		assert.Equal(t, 0, c.Size())
+
+		c.Close()
+		c.Close()
+	})
+
+	t.Run("清空", func(t *testing.T) {
+		c := NewTTLCache[int, int](time.Minute)
+		c.Put(1, 1)
+		c.Put(2, 2)
+		c.Clear()
+		assert.Equal(t, 0, c.Size())
+		assert.Equal(t, 0, c.DeleteExpired())
+		c.Close()
+	})
+
+	t.Run("并发访问", func(t *testing.T) {
+		c := NewTTLCache[int, int](time.Millisecond, TTLOptions[int, int]{
+			CleanupInterval: time.Millisecond,
+			Sliding:         true,
+		})
+		defer c.Close()
+
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					c.Put(i%50, i)
+					_, _ = c.Get(i % 50)
+					if i%100 == 0 {
+						c.DeleteExpired()
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, c.Size(), 50)
+	})
+}
@@ -1,19 +1,19 @@
 package list
 
 import (
-	"cmp"
 	"errors"
+	"iter"
 )
 
 // ArrayList 动态数组实现，类似于Java的ArrayList
-type ArrayList[T cmp.Ordered] struct {
+type ArrayList[T comparable] struct {
 	items    []T
 	length   int
 	capacity int
 }
 
 // NewArrayList 创建新的ArrayList
-func NewArrayList[T cmp.Ordered]() *ArrayList[T] {
+func NewArrayList[T comparable]() *ArrayList[T] {
 	return &ArrayList[T]{
 		items:    make([]T, 0, 10), // 默认初始容量10
 		length:   0,
@@ -22,7 +22,7 @@ func NewArrayList[T cmp.Ordered]() *ArrayList[T] {
 }
 
 // NewArrayListWithCapacity 创建指定初始容量的ArrayList
-func NewArrayListWithCapacity[T cmp.Ordered](capacity int) *ArrayList[T] {
+func NewArrayListWithCapacity[T comparable](capacity int) *ArrayList[T] {
 	if capacity < 1 {
 		capacity = 1
 	}
@@ -147,6 +147,37 @@ func (l *ArrayList[T]) ToSlice() []T {
 	return result
 }
 
+// All 返回从头到尾的迭代器，产出下标和元素
+func (l *ArrayList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < l.length; i++ {
+			if !yield(i, l.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回从尾到头的迭代器，产出下标和元素
+func (l *ArrayList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := l.length - 1; i >= 0; i-- {
+			if !yield(i, l.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// CollectArrayList 从迭代器创建ArrayList
+func CollectArrayList[T comparable](seq iter.Seq[T]) *ArrayList[T] {
+	l := NewArrayList[T]()
+	for v := range seq {
+		l.Add(v)
+	}
+	return l
+}
+
 // Capacity 返回当前容量
 func (l *ArrayList[T]) Capacity() int {
 	return l.capacity
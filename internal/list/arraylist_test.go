@@ -1,6 +1,7 @@
 package list
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -157,4 +158,38 @@ func TestArrayList(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "banana", val)
 	})
+
+	t.Run("非有序类型元素", func(t *testing.T) {
+		type point struct{ x, y int }
+		l := NewArrayList[point]()
+		l.Add(point{1, 2})
+		l.Add(point{3, 4})
+
+		assert.Equal(t, 1, l.IndexOf(point{3, 4}))
+		assert.True(t, l.Contains(point{1, 2}))
+		assert.True(t, l.RemoveValue(point{1, 2}))
+		assert.Equal(t, []point{{3, 4}}, l.ToSlice())
+	})
+}
+
+func TestArrayListIter(t *testing.T) {
+	l := CollectArrayList(slices.Values([]int{1, 2, 3, 4}))
+	assert.Equal(t, []int{1, 2, 3, 4}, l.ToSlice())
+
+	var indexes, values []int
+	for i, v := range l.All() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3}, indexes)
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+
+	values = values[:0]
+	for i, v := range l.Backward() {
+		if i < 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{4, 3}, values)
 }
@@ -1,26 +1,26 @@
 package list
 
 import (
-	"cmp"
 	"errors"
+	"iter"
 )
 
 // Node 双向链表节点
-type Node[T cmp.Ordered] struct {
+type Node[T comparable] struct {
 	Value T
 	Prev  *Node[T]
 	Next  *Node[T]
 }
 
 // LinkedList 双向链表
-type LinkedList[T cmp.Ordered] struct {
+type LinkedList[T comparable] struct {
 	head   *Node[T]
 	tail   *Node[T]
 	length int
 }
 
 // NewLinkedList 创建新的双向链表
-func NewLinkedList[T cmp.Ordered]() *LinkedList[T] {
+func NewLinkedList[T comparable]() *LinkedList[T] {
 	return &LinkedList[T]{}
 }
 
@@ -170,6 +170,41 @@ func (l *LinkedList[T]) ToSlice() []T {
 	return result
 }
 
+// All 返回从头到尾的迭代器，产出下标和元素
+func (l *LinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for current := l.head; current != nil; current = current.Next {
+			if !yield(i, current.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward 返回从尾到头的迭代器，产出下标和元素
+func (l *LinkedList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := l.length - 1
+		for current := l.tail; current != nil; current = current.Prev {
+			if !yield(i, current.Value) {
+				return
+			}
+			i--
+		}
+	}
+}
+
+// CollectLinkedList 从迭代器创建链表
+func CollectLinkedList[T comparable](seq iter.Seq[T]) *LinkedList[T] {
+	l := NewLinkedList[T]()
+	for v := range seq {
+		l.Add(v)
+	}
+	return l
+}
+
 // FromSlice 从切片创建链表
 func (l *LinkedList[T]) FromSlice(values []T) {
 	l.Clear()
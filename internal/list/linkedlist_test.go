@@ -1,6 +1,7 @@
 package list
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -136,3 +137,29 @@ func TestLinkedList(t *testing.T) {
 		assert.Equal(t, values, slice)
 	})
 }
+
+func TestLinkedListIter(t *testing.T) {
+	l := CollectLinkedList(slices.Values([]string{"a", "b", "c"}))
+	assert.Equal(t, 3, l.Size())
+
+	var indexes []int
+	var values []string
+	for i, v := range l.All() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []string{"a", "b", "c"}, values)
+
+	indexes, values = indexes[:0], values[:0]
+	for i, v := range l.Backward() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{2, 1, 0}, indexes)
+	assert.Equal(t, []string{"c", "b", "a"}, values)
+
+	for range NewLinkedList[int]().All() {
+		t.Fatal("空链表不应产出元素")
+	}
+}
@@ -3,26 +3,37 @@ package list
 import (
 	"cmp"
 	"errors"
+	"iter"
 	"math/rand"
 	"time"
 )
 
 // SkipNode 跳表节点
-type SkipNode[T cmp.Ordered] struct {
+type SkipNode[T any] struct {
 	Value T
 	Next  []*SkipNode[T] // 每一层的后继节点指针
 }
 
 // SkipList 跳表
-type SkipList[T cmp.Ordered] struct {
+type SkipList[T any] struct {
 	head     *SkipNode[T]
 	rand     *rand.Rand
 	maxLevel int
 	length   int
+	compare  func(a, b T) int
 }
 
 // NewSkipList 创建新的跳表
 func NewSkipList[T cmp.Ordered]() *SkipList[T] {
+	return NewSkipListFunc(cmp.Compare[T])
+}
+
+// NewSkipListFunc 创建使用比较函数compare排序的跳表
+// compare(a, b) 返回负数表示a排在b之前，0表示两个元素相同；compare为nil时panic
+func NewSkipListFunc[T any](compare func(a, b T) int) *SkipList[T] {
+	if compare == nil {
+		panic("list: 比较函数不能为nil")
+	}
 	// 创建头节点，使用最大层数（实际使用时会动态调整）
 	maxLevel := 16 // 最大16层
 	head := &SkipNode[T]{
@@ -35,6 +46,7 @@ func NewSkipList[T cmp.Ordered]() *SkipList[T] {
 		maxLevel: 0, // 从0层开始
 		length:   0,
 		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		compare:  compare,
 	}
 }
 
@@ -46,14 +58,14 @@ func (s *SkipList[T]) Insert(value T) {
 
 	// 从最高层开始查找插入位置
 	for level := s.maxLevel; level >= 0; level-- {
-		for current.Next[level] != nil && current.Next[level].Value < value {
+		for current.Next[level] != nil && s.compare(current.Next[level].Value, value) < 0 {
 			current = current.Next[level]
 		}
 		update[level] = current
 	}
 
 	// 如果值已存在，不插入
-	if current.Next[0] != nil && current.Next[0].Value == value {
+	if current.Next[0] != nil && s.compare(current.Next[0].Value, value) == 0 {
 		return
 	}
 
@@ -94,14 +106,14 @@ func (s *SkipList[T]) Search(value T) bool {
 
 	// 从最高层开始查找
 	for level := s.maxLevel; level >= 0; level-- {
-		for current.Next[level] != nil && current.Next[level].Value < value {
+		for current.Next[level] != nil && s.compare(current.Next[level].Value, value) < 0 {
 			current = current.Next[level]
 		}
 	}
 
 	// 检查下一个节点是否为目标值
 	current = current.Next[0]
-	return current != nil && current.Value == value
+	return current != nil && s.compare(current.Value, value) == 0
 }
 
 // Remove 删除元素
@@ -111,14 +123,14 @@ func (s *SkipList[T]) Remove(value T) bool {
 
 	// 查找要删除的节点
 	for level := s.maxLevel; level >= 0; level-- {
-		for current.Next[level] != nil && current.Next[level].Value < value {
+		for current.Next[level] != nil && s.compare(current.Next[level].Value, value) < 0 {
 			current = current.Next[level]
 		}
 		update[level] = current
 	}
 
 	current = current.Next[0]
-	if current == nil || current.Value != value {
+	if current == nil || s.compare(current.Value, value) != 0 {
 		return false // 未找到
 	}
 
@@ -165,6 +177,44 @@ func (s *SkipList[T]) ToSlice() []T {
 	return result
 }
 
+// All 返回按升序遍历的迭代器
+func (s *SkipList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := s.head.Next[0]; current != nil; current = current.Next[0] {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Range 返回[from, to)范围内元素的升序迭代器，定位起点的时间复杂度为O(log n)
+func (s *SkipList[T]) Range(from, to T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		current := s.head
+		for level := s.maxLevel; level >= 0; level-- {
+			for current.Next[level] != nil && s.compare(current.Next[level].Value, from) < 0 {
+				current = current.Next[level]
+			}
+		}
+
+		for current = current.Next[0]; current != nil; current = current.Next[0] {
+			if s.compare(current.Value, to) >= 0 || !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSkipList 从迭代器创建跳表
+func CollectSkipList[T cmp.Ordered](seq iter.Seq[T]) *SkipList[T] {
+	s := NewSkipList[T]()
+	for v := range seq {
+		s.Insert(v)
+	}
+	return s
+}
+
 // Clear 清空跳表
 func (s *SkipList[T]) Clear() {
 	maxLevel := 16
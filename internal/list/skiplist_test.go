@@ -1,6 +1,9 @@
 package list
 
 import (
+	"cmp"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -146,4 +149,62 @@ func TestSkipList(t *testing.T) {
 			seen[v] = true
 		}
 	})
+
+	t.Run("自定义比较函数", func(t *testing.T) {
+		type event struct {
+			at   int
+			name string
+		}
+		s := NewSkipListFunc(func(a, b event) int {
+			return cmp.Compare(a.at, b.at)
+		})
+
+		s.Insert(event{3, "c"})
+		s.Insert(event{1, "a"})
+		s.Insert(event{2, "b"})
+		// 比较结果为0视为相同元素
+		s.Insert(event{2, "dup"})
+
+		assert.Equal(t, 3, s.Size())
+		assert.Equal(t, []event{{1, "a"}, {2, "b"}, {3, "c"}}, s.ToSlice())
+		assert.True(t, s.Contains(event{at: 2}))
+		assert.True(t, s.Remove(event{at: 1}))
+
+		min, err := s.Min()
+		assert.NoError(t, err)
+		assert.Equal(t, "b", min.name)
+
+		// 降序跳表
+		desc := NewSkipListFunc(func(a, b string) int {
+			return cmp.Compare(strings.ToLower(b), strings.ToLower(a))
+		})
+		desc.Insert("apple")
+		desc.Insert("Cherry")
+		desc.Insert("banana")
+		desc.Insert("APPLE")
+		assert.Equal(t, []string{"Cherry", "banana", "apple"}, desc.ToSlice())
+	})
+
+	t.Run("比较函数为nil", func(t *testing.T) {
+		assert.Panics(t, func() { NewSkipListFunc[int](nil) })
+	})
+}
+
+func TestSkipListIter(t *testing.T) {
+	s := CollectSkipList(slices.Values([]int{50, 10, 40, 20, 30, 10}))
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, slices.Collect(s.All()))
+
+	assert.Equal(t, []int{20, 30, 40}, slices.Collect(s.Range(15, 50)))
+	assert.Equal(t, []int{10, 20}, slices.Collect(s.Range(0, 30)))
+	assert.Empty(t, slices.Collect(s.Range(60, 100)))
+	assert.Empty(t, slices.Collect(s.Range(30, 30)))
+
+	var first []int
+	for v := range s.All() {
+		first = append(first, v)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{10, 20}, first)
 }
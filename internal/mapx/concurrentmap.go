@@ -0,0 +1,240 @@
+package mapx
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+)
+
+// Map 映射接口，HashMap、LinkedMap和TreeMap都实现了该接口
+type Map[K comparable, V any] interface {
+	Put(key K, value V)
+	Get(key K) (V, bool)
+	Remove(key K)
+	Contains(key K) bool
+	Size() int
+	IsEmpty() bool
+	Clear()
+	Keys() []K
+	Values() []V
+}
+
+var (
+	_ Map[string, int] = (*HashMap[string, int])(nil)
+	_ Map[string, int] = (*LinkedMap[string, int])(nil)
+	_ Map[string, int] = (*TreeMap[string, int])(nil)
+)
+
+// ConcurrentMap 线程安全的映射，使用读写锁保护任意Map实现
+// 提供GetOrPut、ComputeIfAbsent等原子复合操作，避免在外部对Get/Put组合加锁
+type ConcurrentMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  Map[K, V]
+	// mutatingGet 被包装的Map在Get时会修改内部结构，需要持有写锁
+	// 其余持有读锁的方法（Contains、Size、Keys、Values、Range）不会修改被包装的Map
+	mutatingGet bool
+}
+
+// NewConcurrentMap 将m包装为线程安全的映射
+// 包装后不应再直接访问m
+func NewConcurrentMap[K comparable, V any](m Map[K, V]) *ConcurrentMap[K, V] {
+	cm := &ConcurrentMap[K, V]{m: m}
+	// 访问顺序的LinkedMap在Get时会移动节点
+	if lm, ok := m.(*LinkedMap[K, V]); ok && lm.accessOrder {
+		cm.mutatingGet = true
+	}
+	return cm
+}
+
+// NewConcurrentHashMap 创建线程安全的HashMap
+func NewConcurrentHashMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMap[K, V](NewHashMap[K, V]())
+}
+
+// NewConcurrentLinkedMap 创建线程安全的LinkedMap
+func NewConcurrentLinkedMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMap[K, V](NewLinkedMap[K, V]())
+}
+
+// NewConcurrentTreeMap 创建线程安全的TreeMap
+func NewConcurrentTreeMap[K cmp.Ordered, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMap[K, V](NewTreeMap[K, V]())
+}
+
+// Put 添加或更新键值对
+func (cm *ConcurrentMap[K, V]) Put(key K, value V) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.m.Put(key, value)
+}
+
+// Get 获取值
+func (cm *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	if cm.mutatingGet {
+		cm.mu.Lock()
+		defer cm.mu.Unlock()
+		return cm.m.Get(key)
+	}
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.m.Get(key)
+}
+
+// Remove 删除键值对
+func (cm *ConcurrentMap[K, V]) Remove(key K) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.m.Remove(key)
+}
+
+// Contains 检查键是否存在
+func (cm *ConcurrentMap[K, V]) Contains(key K) bool {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.m.Contains(key)
+}
+
+// Size 返回键值对数量
+func (cm *ConcurrentMap[K, V]) Size() int {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.m.Size()
+}
+
+// IsEmpty 检查是否为空
+func (cm *ConcurrentMap[K, V]) IsEmpty() bool {
+	return cm.Size() == 0
+}
+
+// Clear 清空映射
+func (cm *ConcurrentMap[K, V]) Clear() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.m.Clear()
+}
+
+// Keys 返回所有键的快照，顺序与被包装的映射一致
+func (cm *ConcurrentMap[K, V]) Keys() []K {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.m.Keys()
+}
+
+// Values 返回所有值的快照，顺序与被包装的映射一致
+func (cm *ConcurrentMap[K, V]) Values() []V {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.m.Values()
+}
+
+// Range 在读锁保护下依次遍历键值对，fn返回false时停止
+// 遍历不改变访问顺序LinkedMap中键的顺序；fn中不能调用当前映射的写方法，否则会死锁
+func (cm *ConcurrentMap[K, V]) Range(fn func(key K, value V) bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	// HashMap、LinkedMap和TreeMap都提供只读的All，避免逐个键调用Get
+	if seq, ok := cm.m.(interface{ All() iter.Seq2[K, V] }); ok {
+		for key, value := range seq.All() {
+			if !fn(key, value) {
+				return
+			}
+		}
+		return
+	}
+	for _, key := range cm.m.Keys() {
+		value, _ := cm.m.Get(key)
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// GetOrPut 键存在时返回已有的值，否则写入value
+// loaded 表示返回的值是否为已有的值
+func (cm *ConcurrentMap[K, V]) GetOrPut(key K, value V) (actual V, loaded bool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if old, ok := cm.m.Get(key); ok {
+		return old, true
+	}
+	cm.m.Put(key, value)
+	return value, false
+}
+
+// ComputeIfAbsent 键不存在时使用fn计算值并写入，返回键对应的值
+// fn在写锁内执行，同一个键只会被计算一次
+func (cm *ConcurrentMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if old, ok := cm.m.Get(key); ok {
+		return old
+	}
+	value := fn(key)
+	cm.m.Put(key, value)
+	return value
+}
+
+// ComputeIfPresent 键存在时使用fn计算新值
+// fn返回keep为false时删除该键；返回值为新值以及键是否仍然存在
+func (cm *ConcurrentMap[K, V]) ComputeIfPresent(key K, fn func(key K, old V) (value V, keep bool)) (V, bool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	old, ok := cm.m.Get(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	value, keep := fn(key, old)
+	if !keep {
+		cm.m.Remove(key)
+		var zero V
+		return zero, false
+	}
+	cm.m.Put(key, value)
+	return value, true
+}
+
+// Merge 键不存在时写入value，存在时写入fn(old, value)的结果，返回写入的值
+func (cm *ConcurrentMap[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if old, ok := cm.m.Get(key); ok {
+		value = fn(old, value)
+	}
+	cm.m.Put(key, value)
+	return value
+}
+
+// CompareAndSwap 键的当前值等于old时替换为value
+// 与sync.Map一致，值的动态类型必须是可比较的，否则会panic
+func (cm *ConcurrentMap[K, V]) CompareAndSwap(key K, old, value V) bool {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	current, ok := cm.m.Get(key)
+	if !ok || any(current) != any(old) {
+		return false
+	}
+	cm.m.Put(key, value)
+	return true
+}
+
+// CompareAndDelete 键的当前值等于old时删除该键
+// 与sync.Map一致，值的动态类型必须是可比较的，否则会panic
+func (cm *ConcurrentMap[K, V]) CompareAndDelete(key K, old V) bool {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	current, ok := cm.m.Get(key)
+	if !ok || any(current) != any(old) {
+		return false
+	}
+	cm.m.Remove(key)
+	return true
+}
@@ -0,0 +1,206 @@
+package mapx
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentMap(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+
+		assert.True(t, m.IsEmpty())
+		m.Put("a", 1)
+		m.Put("b", 2)
+
+		val, ok := m.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+		assert.True(t, m.Contains("b"))
+		assert.Equal(t, 2, m.Size())
+		assert.Equal(t, []string{"a", "b"}, m.Keys())
+		assert.Equal(t, []int{1, 2}, m.Values())
+
+		m.Remove("a")
+		assert.False(t, m.Contains("a"))
+
+		m.Clear()
+		assert.True(t, m.IsEmpty())
+	})
+
+	t.Run("保持被包装映射的顺序", func(t *testing.T) {
+		tm := NewConcurrentTreeMap[int, string]()
+		tm.Put(3, "c")
+		tm.Put(1, "a")
+		tm.Put(2, "b")
+		assert.Equal(t, []int{1, 2, 3}, tm.Keys())
+
+		lm := NewConcurrentLinkedMap[int, string]()
+		lm.Put(3, "c")
+		lm.Put(1, "a")
+		lm.Put(2, "b")
+		assert.Equal(t, []int{3, 1, 2}, lm.Keys())
+
+		var keys []int
+		tm.Range(func(key int, value string) bool {
+			keys = append(keys, key)
+			return key < 2
+		})
+		assert.Equal(t, []int{1, 2}, keys)
+	})
+
+	t.Run("GetOrPut", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+
+		actual, loaded := m.GetOrPut("a", 1)
+		assert.False(t, loaded)
+		assert.Equal(t, 1, actual)
+
+		actual, loaded = m.GetOrPut("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+	})
+
+	t.Run("ComputeIfAbsent", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+		calls := 0
+		fn := func(key string) int {
+			calls++
+			return len(key)
+		}
+
+		assert.Equal(t, 5, m.ComputeIfAbsent("hello", fn))
+		assert.Equal(t, 5, m.ComputeIfAbsent("hello", fn))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ComputeIfPresent", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+
+		_, ok := m.ComputeIfPresent("a", func(key string, old int) (int, bool) {
+			return old + 1, true
+		})
+		assert.False(t, ok)
+		assert.False(t, m.Contains("a"))
+
+		m.Put("a", 1)
+		val, ok := m.ComputeIfPresent("a", func(key string, old int) (int, bool) {
+			return old + 1, true
+		})
+		assert.True(t, ok)
+		assert.Equal(t, 2, val)
+
+		_, ok = m.ComputeIfPresent("a", func(key string, old int) (int, bool) {
+			return 0, false
+		})
+		assert.False(t, ok)
+		assert.False(t, m.Contains("a"))
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+		sum := func(old, value int) int { return old + value }
+
+		assert.Equal(t, 1, m.Merge("a", 1, sum))
+		assert.Equal(t, 3, m.Merge("a", 2, sum))
+		val, _ := m.Get("a")
+		assert.Equal(t, 3, val)
+	})
+
+	t.Run("CompareAndSwap和CompareAndDelete", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+		assert.False(t, m.CompareAndSwap("a", 0, 1))
+
+		m.Put("a", 1)
+		assert.False(t, m.CompareAndSwap("a", 2, 3))
+		assert.True(t, m.CompareAndSwap("a", 1, 3))
+		val, _ := m.Get("a")
+		assert.Equal(t, 3, val)
+
+		assert.False(t, m.CompareAndDelete("a", 1))
+		assert.True(t, m.CompareAndDelete("a", 3))
+		assert.False(t, m.Contains("a"))
+	})
+
+	t.Run("并发原子操作", func(t *testing.T) {
+		m := NewConcurrentHashMap[string, int]()
+		const goroutines, perGoroutine = 8, 500
+
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perGoroutine; i++ {
+					m.Merge("counter", 1, func(old, value int) int { return old + value })
+					m.ComputeIfAbsent("once", func(string) int { return i })
+					_, _ = m.Get("counter")
+				}
+			}()
+		}
+		wg.Wait()
+
+		val, _ := m.Get("counter")
+		assert.Equal(t, goroutines*perGoroutine, val)
+		assert.Equal(t, 2, m.Size())
+	})
+
+	t.Run("并发读取访问顺序LinkedMap", func(t *testing.T) {
+		// 访问顺序模式下Get会移动节点，使用go test -race检查Get与各个读锁方法之间的数据竞争
+		m := NewConcurrentMap[int, int](NewAccessOrderLinkedMap[int, int]())
+		for i := 0; i < 10; i++ {
+			m.Put(i, i)
+		}
+
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 500; i++ {
+					val, ok := m.Get(i % 10)
+					assert.True(t, ok)
+					assert.Equal(t, i%10, val)
+					// 读锁路径与Get并发执行
+					assert.True(t, m.Contains(i%10))
+					assert.Len(t, m.Keys(), 10)
+					assert.Len(t, m.Values(), 10)
+					assert.Equal(t, 10, m.Size())
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 10, m.Size())
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, m.Keys())
+	})
+
+	t.Run("并发遍历访问顺序LinkedMap", func(t *testing.T) {
+		m := NewConcurrentMap[int, int](NewAccessOrderLinkedMap[int, int]())
+		for i := 0; i < 10; i++ {
+			m.Put(i, i)
+		}
+
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					sum := 0
+					m.Range(func(key, value int) bool {
+						sum += value
+						return true
+					})
+					assert.Equal(t, 45, sum)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// 遍历不改变访问顺序
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, m.Keys())
+	})
+}
@@ -1,5 +1,7 @@
 package mapx
 
+import "iter"
+
 // HashMap 基于Go内置map实现的增强版映射
 // 提供可预测的迭代顺序
 type HashMap[K comparable, V any] struct {
@@ -82,6 +84,26 @@ func (m *HashMap[K, V]) Values() []V {
 	return result
 }
 
+// All 返回按插入顺序遍历键值对的迭代器
+func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range m.keys {
+			if !yield(k, m.items[k]) {
+				return
+			}
+		}
+	}
+}
+
+// CollectHashMap 从迭代器创建HashMap，重复的键保留最后一个值
+func CollectHashMap[K comparable, V any](seq iter.Seq2[K, V]) *HashMap[K, V] {
+	m := NewHashMap[K, V]()
+	for k, v := range seq {
+		m.Put(k, v)
+	}
+	return m
+}
+
 // ToMap 转换为Go内置map
 func (m *HashMap[K, V]) ToMap() map[K]V {
 	result := make(map[K]V, len(m.items))
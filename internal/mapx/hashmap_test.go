@@ -1,6 +1,7 @@
 package mapx
 
 import (
+	"maps"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -117,3 +118,19 @@ func TestHashMap(t *testing.T) {
 		assert.Equal(t, []int{1, 2, 3}, keys)
 	})
 }
+
+func TestHashMapIter(t *testing.T) {
+	m := CollectHashMap(maps.All(map[string]int{"a": 1}))
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	var keys []string
+	var sum int
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		sum += v
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+	assert.Equal(t, 6, sum)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, maps.Collect(m.All()))
+}
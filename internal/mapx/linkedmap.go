@@ -1,5 +1,7 @@
 package mapx
 
+import "iter"
+
 // LinkedNode 链表节点
 type LinkedNode[K comparable, V any] struct {
 	Key   K
@@ -9,11 +11,13 @@ type LinkedNode[K comparable, V any] struct {
 }
 
 // LinkedMap 保持插入顺序的映射
+// 访问顺序模式下，Get会将被访问的键移到尾部，头部即最久未访问的键
 type LinkedMap[K comparable, V any] struct {
-	items  map[K]*LinkedNode[K, V]
-	head   *LinkedNode[K, V]
-	tail   *LinkedNode[K, V]
-	length int
+	items       map[K]*LinkedNode[K, V]
+	head        *LinkedNode[K, V]
+	tail        *LinkedNode[K, V]
+	length      int
+	accessOrder bool
 }
 
 // NewLinkedMap 创建新的LinkedMap
@@ -26,6 +30,13 @@ func NewLinkedMap[K comparable, V any]() *LinkedMap[K, V] {
 	}
 }
 
+// NewAccessOrderLinkedMap 创建按访问顺序排列的LinkedMap
+func NewAccessOrderLinkedMap[K comparable, V any]() *LinkedMap[K, V] {
+	lm := NewLinkedMap[K, V]()
+	lm.accessOrder = true
+	return lm
+}
+
 // Put 添加或更新键值对
 func (lm *LinkedMap[K, V]) Put(key K, value V) {
 	if node, exists := lm.items[key]; exists {
@@ -46,8 +57,20 @@ func (lm *LinkedMap[K, V]) Put(key K, value V) {
 	}
 }
 
-// Get 获取值
+// Get 获取值，访问顺序模式下会将该键移到尾部
 func (lm *LinkedMap[K, V]) Get(key K) (V, bool) {
+	if node, exists := lm.items[key]; exists {
+		if lm.accessOrder {
+			lm.moveToTail(node)
+		}
+		return node.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek 获取值，不改变键的顺序
+func (lm *LinkedMap[K, V]) Peek(key K) (V, bool) {
 	if node, exists := lm.items[key]; exists {
 		return node.Value, true
 	}
@@ -110,6 +133,37 @@ func (lm *LinkedMap[K, V]) Values() []V {
 	return values
 }
 
+// All 返回从头到尾遍历键值对的迭代器，不改变访问顺序
+func (lm *LinkedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for current := lm.head; current != nil; current = current.Next {
+			if !yield(current.Key, current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回从尾到头遍历键值对的迭代器，不改变访问顺序
+func (lm *LinkedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for current := lm.tail; current != nil; current = current.Prev {
+			if !yield(current.Key, current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// CollectLinkedMap 从迭代器创建LinkedMap，重复的键保留最后一个值
+func CollectLinkedMap[K comparable, V any](seq iter.Seq2[K, V]) *LinkedMap[K, V] {
+	lm := NewLinkedMap[K, V]()
+	for k, v := range seq {
+		lm.Put(k, v)
+	}
+	return lm
+}
+
 // Entries 返回所有键值对（按插入顺序）
 func (lm *LinkedMap[K, V]) Entries() []struct {
 	K K
@@ -150,6 +204,20 @@ func (lm *LinkedMap[K, V]) Last() (K, V, bool) {
 	return lm.tail.Key, lm.tail.Value, true
 }
 
+// RemoveFirst 删除并返回第一个键值对
+func (lm *LinkedMap[K, V]) RemoveFirst() (K, V, bool) {
+	if lm.head == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	node := lm.head
+	lm.removeNode(node)
+	delete(lm.items, node.Key)
+	lm.length--
+	return node.Key, node.Value, true
+}
+
 // ToMap 转换为Go内置map
 func (lm *LinkedMap[K, V]) ToMap() map[K]V {
 	result := make(map[K]V, lm.length)
@@ -1,6 +1,7 @@
 package mapx
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -223,4 +224,78 @@ func TestLinkedMap(t *testing.T) {
 		keys := lm.Keys()
 		assert.Equal(t, []int{3, 1, 2}, keys)
 	})
+
+	t.Run("访问顺序模式", func(t *testing.T) {
+		lm := NewAccessOrderLinkedMap[string, int]()
+		lm.Put("a", 1)
+		lm.Put("b", 2)
+		lm.Put("c", 3)
+
+		// Get会将键移到尾部
+		_, _ = lm.Get("a")
+		assert.Equal(t, []string{"b", "c", "a"}, lm.Keys())
+
+		// Peek不改变顺序
+		val, ok := lm.Peek("b")
+		assert.True(t, ok)
+		assert.Equal(t, 2, val)
+		assert.Equal(t, []string{"b", "c", "a"}, lm.Keys())
+
+		// 插入顺序模式下Get不改变顺序
+		im := NewLinkedMap[string, int]()
+		im.Put("a", 1)
+		im.Put("b", 2)
+		_, _ = im.Get("a")
+		assert.Equal(t, []string{"a", "b"}, im.Keys())
+	})
+
+	t.Run("RemoveFirst", func(t *testing.T) {
+		lm := NewLinkedMap[string, int]()
+		_, _, ok := lm.RemoveFirst()
+		assert.False(t, ok)
+
+		lm.Put("a", 1)
+		lm.Put("b", 2)
+
+		key, val, ok := lm.RemoveFirst()
+		assert.True(t, ok)
+		assert.Equal(t, "a", key)
+		assert.Equal(t, 1, val)
+		assert.False(t, lm.Contains("a"))
+		assert.Equal(t, 1, lm.Size())
+
+		key, _, _ = lm.RemoveFirst()
+		assert.Equal(t, "b", key)
+		assert.True(t, lm.IsEmpty())
+		_, _, ok = lm.Last()
+		assert.False(t, ok)
+	})
+}
+
+func TestLinkedMapIter(t *testing.T) {
+	lm := CollectLinkedMap(slices.All([]string{"x", "y", "z"}))
+	assert.Equal(t, []int{0, 1, 2}, lm.Keys())
+
+	var values []string
+	for _, v := range lm.All() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []string{"x", "y", "z"}, values)
+
+	values = values[:0]
+	for k, v := range lm.Backward() {
+		if k == 0 {
+			break
+		}
+		values = append(values, v)
+	}
+	assert.Equal(t, []string{"z", "y"}, values)
+
+	// 访问顺序模式下遍历不改变顺序
+	am := NewAccessOrderLinkedMap[int, int]()
+	am.Put(1, 1)
+	am.Put(2, 2)
+	for range am.All() {
+	}
+	assert.Equal(t, []int{1, 2}, am.Keys())
 }
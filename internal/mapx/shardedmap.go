@@ -0,0 +1,331 @@
+package mapx
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// defaultShardCount 默认分片数量
+const defaultShardCount = 32
+
+// Hasher 键的哈希函数，相同的键必须返回相同的哈希值
+type Hasher[K comparable] func(key K) uint64
+
+// mapShard ShardedMap中独立加锁的分片
+type mapShard[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]V
+}
+
+// ShardedMap 分片并发哈希映射
+// 键按哈希值分布到多个独立加锁的分片中，适用于高并发读写的场景；不保证迭代顺序
+type ShardedMap[K comparable, V any] struct {
+	shards []*mapShard[K, V]
+	mask   uint64
+	hasher Hasher[K]
+}
+
+// NewShardedMap 创建分片映射，使用默认的哈希函数
+// shardCount 会向上取整为2的幂，小于等于0时使用默认值32
+func NewShardedMap[K comparable, V any](shardCount int) *ShardedMap[K, V] {
+	return NewShardedMapWithHasher[K, V](shardCount, DefaultHasher[K]())
+}
+
+// NewShardedMapWithHasher 创建使用自定义哈希函数的分片映射
+func NewShardedMapWithHasher[K comparable, V any](shardCount int, hasher Hasher[K]) *ShardedMap[K, V] {
+	if shardCount <= 0 {
+		shardCount = defaultShardCount
+	}
+	n := 1
+	for n < shardCount {
+		n <<= 1
+	}
+
+	shards := make([]*mapShard[K, V], n)
+	for i := range shards {
+		shards[i] = &mapShard[K, V]{items: make(map[K]V)}
+	}
+	return &ShardedMap[K, V]{
+		shards: shards,
+		mask:   uint64(n - 1),
+		hasher: hasher,
+	}
+}
+
+// DefaultHasher 返回键类型K的默认哈希函数
+// 字符串使用maphash，整数和浮点数使用位混合，浮点数的+0和-0哈希相同
+// 结构体、数组等其他类型通过反射逐字段哈希，性能较差，建议自定义Hasher
+func DefaultHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		switch k := any(key).(type) {
+		case string:
+			return maphash.String(seed, k)
+		case int:
+			return mix64(uint64(k))
+		case int8:
+			return mix64(uint64(k))
+		case int16:
+			return mix64(uint64(k))
+		case int32:
+			return mix64(uint64(k))
+		case int64:
+			return mix64(uint64(k))
+		case uint:
+			return mix64(uint64(k))
+		case uint8:
+			return mix64(uint64(k))
+		case uint16:
+			return mix64(uint64(k))
+		case uint32:
+			return mix64(uint64(k))
+		case uint64:
+			return mix64(k)
+		case uintptr:
+			return mix64(uint64(k))
+		case float32:
+			return hashFloat(float64(k))
+		case float64:
+			return hashFloat(k)
+		case complex64:
+			return hashComplex(complex128(k))
+		case complex128:
+			return hashComplex(k)
+		case bool:
+			if k {
+				return mix64(1)
+			}
+			return mix64(0)
+		default:
+			return hashValue(seed, reflect.ValueOf(key))
+		}
+	}
+}
+
+// hashFloat 浮点数哈希，+0和-0相等，需要返回相同的哈希值
+func hashFloat(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return mix64(math.Float64bits(f))
+}
+
+// hashComplex 复数哈希，实部和虚部分别按浮点数规则哈希
+func hashComplex(c complex128) uint64 {
+	return mix64(hashFloat(real(c))*31 ^ hashFloat(imag(c)))
+}
+
+// hashValue 通过反射计算任意可比较值的哈希，相等的值返回相同的哈希值
+// 结构体和数组逐个字段或元素组合哈希，接口按其动态值哈希
+func hashValue(seed maphash.Seed, v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.String:
+		return maphash.String(seed, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return hashFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return hashComplex(v.Complex())
+	case reflect.Bool:
+		if v.Bool() {
+			return mix64(1)
+		}
+		return mix64(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mix64(uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return hashValue(seed, v.Elem())
+	case reflect.Struct:
+		h := uint64(v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			h = mix64(h ^ hashValue(seed, v.Field(i)))
+		}
+		return h
+	case reflect.Array:
+		h := uint64(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			h = mix64(h ^ hashValue(seed, v.Index(i)))
+		}
+		return h
+	}
+	return 0
+}
+
+// mix64 整数位混合（splitmix64终结函数），使相邻整数分布到不同分片
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// shard 返回键所在的分片
+func (m *ShardedMap[K, V]) shard(key K) *mapShard[K, V] {
+	return m.shards[m.hasher(key)&m.mask]
+}
+
+// ShardCount 返回分片数量
+func (m *ShardedMap[K, V]) ShardCount() int {
+	return len(m.shards)
+}
+
+// Put 添加或更新键值对
+func (m *ShardedMap[K, V]) Put(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	s.items[key] = value
+	s.mu.Unlock()
+}
+
+// Get 获取值
+func (m *ShardedMap[K, V]) Get(key K) (V, bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	val, ok := s.items[key]
+	s.mu.RUnlock()
+	return val, ok
+}
+
+// Remove 删除键值对
+func (m *ShardedMap[K, V]) Remove(key K) {
+	s := m.shard(key)
+	s.mu.Lock()
+	delete(s.items, key)
+	s.mu.Unlock()
+}
+
+// Contains 检查键是否存在
+func (m *ShardedMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// GetOrPut 键存在时返回已有的值，否则写入value
+// loaded 表示返回的值是否为已有的值
+func (m *ShardedMap[K, V]) GetOrPut(key K, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.items[key]; ok {
+		return old, true
+	}
+	s.items[key] = value
+	return value, false
+}
+
+// ComputeIfAbsent 键不存在时使用fn计算值并写入，返回键对应的值
+// fn在分片的写锁内执行，同一个键只会被计算一次
+func (m *ShardedMap[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.items[key]; ok {
+		return old
+	}
+	value := fn(key)
+	s.items[key] = value
+	return value
+}
+
+// Len 返回键值对数量
+// 各分片依次加锁统计，并发写入时结果只是近似值
+func (m *ShardedMap[K, V]) Len() int {
+	n := 0
+	for _, s := range m.shards {
+		s.mu.RLock()
+		n += len(s.items)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Size 返回键值对数量，与Len相同
+func (m *ShardedMap[K, V]) Size() int {
+	return m.Len()
+}
+
+// IsEmpty 检查是否为空
+func (m *ShardedMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Clear 清空映射
+func (m *ShardedMap[K, V]) Clear() {
+	for _, s := range m.shards {
+		s.mu.Lock()
+		clear(s.items)
+		s.mu.Unlock()
+	}
+}
+
+// Keys 返回所有键，顺序不确定
+func (m *ShardedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	for _, s := range m.shards {
+		s.mu.RLock()
+		for k := range s.items {
+			keys = append(keys, k)
+		}
+		s.mu.RUnlock()
+	}
+	return keys
+}
+
+// Range 逐个分片遍历键值对，fn返回false时停止
+// 每个分片先复制再回调，fn中可以安全地修改当前映射；遍历期间的并发修改可能不可见
+func (m *ShardedMap[K, V]) Range(fn func(key K, value V) bool) {
+	var keys []K
+	var values []V
+	for _, s := range m.shards {
+		keys, values = keys[:0], values[:0]
+		s.mu.RLock()
+		for k, v := range s.items {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		s.mu.RUnlock()
+
+		for i := range keys {
+			if !fn(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot 返回所有键值对的副本
+// 快照期间同时持有所有分片的读锁，结果是某一时刻的一致视图
+func (m *ShardedMap[K, V]) Snapshot() map[K]V {
+	for _, s := range m.shards {
+		s.mu.RLock()
+	}
+	defer func() {
+		for _, s := range m.shards {
+			s.mu.RUnlock()
+		}
+	}()
+
+	n := 0
+	for _, s := range m.shards {
+		n += len(s.items)
+	}
+	result := make(map[K]V, n)
+	for _, s := range m.shards {
+		for k, v := range s.items {
+			result[k] = v
+		}
+	}
+	return result
+}
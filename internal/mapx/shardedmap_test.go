@@ -0,0 +1,249 @@
+package mapx
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedMap(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		m := NewShardedMap[string, int](4)
+		assert.Equal(t, 4, m.ShardCount())
+		assert.True(t, m.IsEmpty())
+
+		m.Put("a", 1)
+		m.Put("b", 2)
+		m.Put("a", 3)
+
+		val, ok := m.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 3, val)
+		assert.True(t, m.Contains("b"))
+		assert.Equal(t, 2, m.Len())
+		assert.Equal(t, 2, m.Size())
+		assert.ElementsMatch(t, []string{"a", "b"}, m.Keys())
+
+		m.Remove("a")
+		assert.False(t, m.Contains("a"))
+
+		m.Clear()
+		assert.True(t, m.IsEmpty())
+	})
+
+	t.Run("分片数量", func(t *testing.T) {
+		assert.Equal(t, defaultShardCount, NewShardedMap[int, int](0).ShardCount())
+		assert.Equal(t, 8, NewShardedMap[int, int](5).ShardCount())
+		assert.Equal(t, 1, NewShardedMap[int, int](1).ShardCount())
+	})
+
+	t.Run("自定义哈希函数", func(t *testing.T) {
+		type point struct{ x, y int }
+		m := NewShardedMapWithHasher[point, string](4, func(p point) uint64 {
+			return uint64(p.x*31 + p.y)
+		})
+
+		m.Put(point{1, 2}, "a")
+		m.Put(point{2, 1}, "b")
+		val, ok := m.Get(point{1, 2})
+		assert.True(t, ok)
+		assert.Equal(t, "a", val)
+		assert.Equal(t, 2, m.Len())
+	})
+
+	t.Run("默认哈希函数", func(t *testing.T) {
+		type point struct{ x, y int }
+		hasher := DefaultHasher[point]()
+		assert.Equal(t, hasher(point{1, 2}), hasher(point{1, 2}))
+		assert.NotEqual(t, hasher(point{1, 2}), hasher(point{2, 1}))
+
+		// 相等的键哈希值必须相同
+		zero, negZero := 0.0, math.Copysign(0, -1)
+		floats := NewShardedMap[float64, int](8)
+		floats.Put(zero, 1)
+		floats.Put(negZero, 2)
+		assert.Equal(t, 1, floats.Len())
+
+		type key struct {
+			name  string
+			score float64
+			pair  [2]complex128
+			any   interface{}
+		}
+		keyHasher := DefaultHasher[key]()
+		assert.Equal(t,
+			keyHasher(key{"a", zero, [2]complex128{complex(zero, negZero)}, zero}),
+			keyHasher(key{"a", negZero, [2]complex128{complex(negZero, zero)}, negZero}))
+		assert.NotEqual(t, keyHasher(key{name: "a"}), keyHasher(key{name: "b"}))
+
+		// 相邻整数应分布到多个分片
+		m := NewShardedMap[int, int](8)
+		used := make(map[uint64]bool)
+		for i := 0; i < 64; i++ {
+			used[m.hasher(i)&m.mask] = true
+		}
+		assert.Len(t, used, 8)
+	})
+
+	t.Run("GetOrPut和ComputeIfAbsent", func(t *testing.T) {
+		m := NewShardedMap[string, int](0)
+
+		actual, loaded := m.GetOrPut("a", 1)
+		assert.False(t, loaded)
+		assert.Equal(t, 1, actual)
+		actual, loaded = m.GetOrPut("a", 2)
+		assert.True(t, loaded)
+		assert.Equal(t, 1, actual)
+
+		calls := 0
+		fn := func(key string) int {
+			calls++
+			return len(key)
+		}
+		assert.Equal(t, 5, m.ComputeIfAbsent("hello", fn))
+		assert.Equal(t, 5, m.ComputeIfAbsent("hello", fn))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Range和Snapshot", func(t *testing.T) {
+		m := NewShardedMap[int, int](4)
+		for i := 0; i < 100; i++ {
+			m.Put(i, i*i)
+		}
+
+		seen := make(map[int]int)
+		m.Range(func(key, value int) bool {
+			seen[key] = value
+			return true
+		})
+		assert.Len(t, seen, 100)
+		assert.Equal(t, 81, seen[9])
+
+		count := 0
+		m.Range(func(key, value int) bool {
+			count++
+			return count < 10
+		})
+		assert.Equal(t, 10, count)
+
+		// 回调中修改映射不会死锁
+		m.Range(func(key, value int) bool {
+			if key%2 == 0 {
+				m.Remove(key)
+			}
+			return true
+		})
+		assert.Equal(t, 50, m.Len())
+
+		snapshot := m.Snapshot()
+		assert.Len(t, snapshot, 50)
+		m.Clear()
+		assert.Len(t, snapshot, 50)
+		assert.Equal(t, 1, snapshot[1])
+	})
+
+	t.Run("并发读写", func(t *testing.T) {
+		m := NewShardedMap[int, int](16)
+		const goroutines, perGoroutine = 8, 1000
+
+		var wg sync.WaitGroup
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < perGoroutine; i++ {
+					key := g*perGoroutine + i
+					m.Put(key, i)
+					_, _ = m.Get(key)
+					if i%10 == 0 {
+						_ = m.Len()
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		assert.Equal(t, goroutines*perGoroutine, m.Len())
+	})
+}
+
+const benchKeyCount = 1024
+
+func benchKeys() []string {
+	keys := make([]string, benchKeyCount)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+	return keys
+}
+
+// 90%读、10%写的混合负载
+
+func BenchmarkShardedMap(b *testing.B) {
+	keys := benchKeys()
+	m := NewShardedMap[string, int](0)
+	for i, k := range keys {
+		m.Put(k, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i%benchKeyCount]
+			if i%10 == 0 {
+				m.Put(k, i)
+			} else {
+				_, _ = m.Get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMap(b *testing.B) {
+	keys := benchKeys()
+	var m sync.Map
+	for i, k := range keys {
+		m.Store(k, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i%benchKeyCount]
+			if i%10 == 0 {
+				m.Store(k, i)
+			} else {
+				_, _ = m.Load(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentHashMap(b *testing.B) {
+	keys := benchKeys()
+	m := NewConcurrentHashMap[string, int]()
+	for i, k := range keys {
+		m.Put(k, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i%benchKeyCount]
+			if i%10 == 0 {
+				m.Put(k, i)
+			} else {
+				_, _ = m.Get(k)
+			}
+			i++
+		}
+	})
+}
@@ -3,41 +3,97 @@ package mapx
 import (
 	"cmp"
 	"errors"
+	"iter"
+)
+
+// 红黑树节点颜色
+const (
+	red   = true
+	black = false
 )
 
 // TreeNode 树节点
-type TreeNode[K cmp.Ordered, V any] struct {
+type TreeNode[K, V any] struct {
 	Key    K
 	Value  V
 	Left   *TreeNode[K, V]
 	Right  *TreeNode[K, V]
 	Parent *TreeNode[K, V]
 	Color  bool // true = Red, false = Black
+	size   int  // 以该节点为根的子树中的节点数，用于顺序统计
 }
 
-// TreeMap 有序映射，基于二叉搜索树
-type TreeMap[K cmp.Ordered, V any] struct {
-	root   *TreeNode[K, V]
-	length int
+// TreeMap 有序映射，基于红黑树
+// 查找、插入和删除的时间复杂度均为O(log n)，所有操作都以迭代方式实现，不会因树高导致栈增长
+// 每个节点记录子树大小，Rank和Select同样为O(log n)
+type TreeMap[K, V any] struct {
+	root    *TreeNode[K, V]
+	length  int
+	compare func(a, b K) int
 }
 
 // NewTreeMap 创建新的TreeMap
 func NewTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewTreeMapFunc 创建使用比较函数compare排序的TreeMap，可用于time.Time、结构体等非有序类型的键
+// compare(a, b) 返回负数表示a排在b之前，0表示两个键相同；compare为nil时panic
+func NewTreeMapFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	if compare == nil {
+		panic("mapx: 比较函数不能为nil")
+	}
 	return &TreeMap[K, V]{
-		root:   nil,
-		length: 0,
+		root:    nil,
+		length:  0,
+		compare: compare,
 	}
 }
 
 // Put 添加或更新键值对
 func (tm *TreeMap[K, V]) Put(key K, value V) {
-	tm.root = tm.insert(tm.root, key, value, nil)
+	var parent *TreeNode[K, V]
+	current := tm.root
+	c := 0
+	for current != nil {
+		parent = current
+		c = tm.compare(key, current.Key)
+		switch {
+		case c < 0:
+			current = current.Left
+		case c > 0:
+			current = current.Right
+		default:
+			// 键已存在，更新值
+			current.Value = value
+			return
+		}
+	}
+
+	node := &TreeNode[K, V]{
+		Key:    key,
+		Value:  value,
+		Parent: parent,
+		Color:  red, // 新节点默认为红色
+		size:   1,
+	}
+	if parent == nil {
+		tm.root = node
+	} else if c < 0 {
+		parent.Left = node
+	} else {
+		parent.Right = node
+	}
+	for p := parent; p != nil; p = p.Parent {
+		p.size++
+	}
 	tm.length++
+	tm.insertFixup(node)
 }
 
 // Get 获取值
 func (tm *TreeMap[K, V]) Get(key K) (V, bool) {
-	node := tm.search(tm.root, key)
+	node := tm.search(key)
 	if node == nil {
 		var zero V
 		return zero, false
@@ -47,15 +103,15 @@ func (tm *TreeMap[K, V]) Get(key K) (V, bool) {
 
 // Remove 删除键
 func (tm *TreeMap[K, V]) Remove(key K) {
-	deleted := tm.delete(tm.root, key)
-	if deleted {
-		tm.length--
+	node := tm.search(key)
+	if node != nil {
+		tm.deleteNode(node)
 	}
 }
 
 // Contains 检查键是否存在
 func (tm *TreeMap[K, V]) Contains(key K) bool {
-	return tm.search(tm.root, key) != nil
+	return tm.search(key) != nil
 }
 
 // Size 返回键值对数量
@@ -76,15 +132,19 @@ func (tm *TreeMap[K, V]) Clear() {
 
 // Keys 返回所有键（按排序顺序）
 func (tm *TreeMap[K, V]) Keys() []K {
-	var keys []K
-	tm.inOrderTraversal(tm.root, &keys)
+	keys := make([]K, 0, tm.length)
+	for node := tm.first(); node != nil; node = successor(node) {
+		keys = append(keys, node.Key)
+	}
 	return keys
 }
 
 // Values 返回所有值（按排序顺序）
 func (tm *TreeMap[K, V]) Values() []V {
-	var values []V
-	tm.inOrderTraversalValues(tm.root, &values)
+	values := make([]V, 0, tm.length)
+	for node := tm.first(); node != nil; node = successor(node) {
+		values = append(values, node.Value)
+	}
 	return values
 }
 
@@ -93,22 +153,68 @@ func (tm *TreeMap[K, V]) Entries() []struct {
 	K K
 	V V
 } {
-	var entries []struct {
+	entries := make([]struct {
 		K K
 		V V
+	}, 0, tm.length)
+	for node := tm.first(); node != nil; node = successor(node) {
+		entries = append(entries, struct {
+			K K
+			V V
+		}{K: node.Key, V: node.Value})
 	}
-	tm.inOrderTraversalEntries(tm.root, &entries)
 	return entries
 }
 
+// All 返回按键升序遍历键值对的迭代器
+func (tm *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := tm.first(); node != nil; node = successor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回按键降序遍历键值对的迭代器
+func (tm *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := tm.last(); node != nil; node = predecessor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Range 返回键在[from, to)范围内的升序迭代器，不复制节点
+func (tm *TreeMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := tm.ceilingNode(from); node != nil; node = successor(node) {
+			if tm.compare(node.Key, to) >= 0 || !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// CollectTreeMap 从迭代器创建TreeMap，重复的键保留最后一个值
+func CollectTreeMap[K cmp.Ordered, V any](seq iter.Seq2[K, V]) *TreeMap[K, V] {
+	tm := NewTreeMap[K, V]()
+	for k, v := range seq {
+		tm.Put(k, v)
+	}
+	return tm
+}
+
 // Min 返回最小键
 func (tm *TreeMap[K, V]) Min() (K, error) {
 	if tm.root == nil {
 		var zero K
 		return zero, errors.New("tree map is empty")
 	}
-	node := tm.min(tm.root)
-	return node.Key, nil
+	return tm.first().Key, nil
 }
 
 // Max 返回最大键
@@ -117,151 +223,442 @@ func (tm *TreeMap[K, V]) Max() (K, error) {
 		var zero K
 		return zero, errors.New("tree map is empty")
 	}
-	node := tm.max(tm.root)
-	return node.Key, nil
+	return tm.last().Key, nil
 }
 
-// insert 插入节点
-func (tm *TreeMap[K, V]) insert(node *TreeNode[K, V], key K, value V, parent *TreeNode[K, V]) *TreeNode[K, V] {
-	if node == nil {
-		return &TreeNode[K, V]{
-			Key:    key,
-			Value:  value,
-			Parent: parent,
-			Color:  true, // 新节点默认为红色
+// Floor 返回小于等于key的最大键值对
+func (tm *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	return entryOf(tm.floorNode(key))
+}
+
+// Ceiling 返回大于等于key的最小键值对
+func (tm *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(tm.ceilingNode(key))
+}
+
+// Lower 返回严格小于key的最大键值对
+func (tm *TreeMap[K, V]) Lower(key K) (K, V, bool) {
+	return entryOf(tm.lowerNode(key))
+}
+
+// Higher 返回严格大于key的最小键值对
+func (tm *TreeMap[K, V]) Higher(key K) (K, V, bool) {
+	return entryOf(tm.higherNode(key))
+}
+
+// First 返回最小的键值对
+func (tm *TreeMap[K, V]) First() (K, V, bool) {
+	return entryOf(tm.first())
+}
+
+// Last 返回最大的键值对
+func (tm *TreeMap[K, V]) Last() (K, V, bool) {
+	return entryOf(tm.last())
+}
+
+// PollFirst 删除并返回最小的键值对
+func (tm *TreeMap[K, V]) PollFirst() (K, V, bool) {
+	return tm.poll(tm.first())
+}
+
+// PollLast 删除并返回最大的键值对
+func (tm *TreeMap[K, V]) PollLast() (K, V, bool) {
+	return tm.poll(tm.last())
+}
+
+// Rank 返回严格小于key的键的数量，即key在有序序列中的下标（key不存在时为其插入位置）
+func (tm *TreeMap[K, V]) Rank(key K) int {
+	rank := 0
+	current := tm.root
+	for current != nil {
+		if tm.compare(key, current.Key) <= 0 {
+			current = current.Left
+		} else {
+			rank += nodeSize(current.Left) + 1
+			current = current.Right
 		}
 	}
+	return rank
+}
 
-	if key < node.Key {
-		node.Left = tm.insert(node.Left, key, value, node)
-	} else if key > node.Key {
-		node.Right = tm.insert(node.Right, key, value, node)
-	} else {
-		// 键已存在，更新值
-		node.Value = value
-		tm.length-- // 抵消后面的length++
+// Select 返回有序序列中下标为index的键值对，下标越界时返回false
+func (tm *TreeMap[K, V]) Select(index int) (K, V, bool) {
+	if index < 0 || index >= tm.length {
+		return entryOf[K, V](nil)
 	}
 
-	return node
+	current := tm.root
+	for {
+		leftSize := nodeSize(current.Left)
+		switch {
+		case index < leftSize:
+			current = current.Left
+		case index == leftSize:
+			return entryOf(current)
+		default:
+			index -= leftSize + 1
+			current = current.Right
+		}
+	}
 }
 
 // search 搜索节点
-func (tm *TreeMap[K, V]) search(node *TreeNode[K, V], key K) *TreeNode[K, V] {
-	if node == nil {
+func (tm *TreeMap[K, V]) search(key K) *TreeNode[K, V] {
+	current := tm.root
+	for current != nil {
+		c := tm.compare(key, current.Key)
+		switch {
+		case c < 0:
+			current = current.Left
+		case c > 0:
+			current = current.Right
+		default:
+			return current
+		}
+	}
+	return nil
+}
+
+// first 返回最小节点，树为空时返回nil
+func (tm *TreeMap[K, V]) first() *TreeNode[K, V] {
+	if tm.root == nil {
 		return nil
 	}
+	return minNode(tm.root)
+}
 
-	if key < node.Key {
-		return tm.search(node.Left, key)
-	} else if key > node.Key {
-		return tm.search(node.Right, key)
-	} else {
-		return node
+// last 返回最大节点，树为空时返回nil
+func (tm *TreeMap[K, V]) last() *TreeNode[K, V] {
+	if tm.root == nil {
+		return nil
 	}
+	return maxNode(tm.root)
 }
 
-// delete 删除节点
-func (tm *TreeMap[K, V]) delete(node *TreeNode[K, V], key K) bool {
-	if node == nil {
-		return false
+// floorNode 返回小于等于key的最大节点
+func (tm *TreeMap[K, V]) floorNode(key K) *TreeNode[K, V] {
+	var result *TreeNode[K, V]
+	current := tm.root
+	for current != nil {
+		c := tm.compare(key, current.Key)
+		switch {
+		case c < 0:
+			current = current.Left
+		case c > 0:
+			result = current
+			current = current.Right
+		default:
+			return current
+		}
+	}
+	return result
+}
+
+// ceilingNode 返回大于等于key的最小节点
+func (tm *TreeMap[K, V]) ceilingNode(key K) *TreeNode[K, V] {
+	var result *TreeNode[K, V]
+	current := tm.root
+	for current != nil {
+		c := tm.compare(key, current.Key)
+		switch {
+		case c < 0:
+			result = current
+			current = current.Left
+		case c > 0:
+			current = current.Right
+		default:
+			return current
+		}
 	}
+	return result
+}
 
-	if key < node.Key {
-		return tm.delete(node.Left, key)
-	} else if key > node.Key {
-		return tm.delete(node.Right, key)
-	} else {
-		// 找到要删除的节点
-		if node.Left == nil && node.Right == nil {
-			// 叶子节点
-			if node.Parent != nil {
-				if node.Parent.Left == node {
-					node.Parent.Left = nil
-				} else {
-					node.Parent.Right = nil
-				}
+// lowerNode 返回严格小于key的最大节点
+func (tm *TreeMap[K, V]) lowerNode(key K) *TreeNode[K, V] {
+	var result *TreeNode[K, V]
+	current := tm.root
+	for current != nil {
+		if tm.compare(key, current.Key) <= 0 {
+			current = current.Left
+		} else {
+			result = current
+			current = current.Right
+		}
+	}
+	return result
+}
+
+// higherNode 返回严格大于key的最小节点
+func (tm *TreeMap[K, V]) higherNode(key K) *TreeNode[K, V] {
+	var result *TreeNode[K, V]
+	current := tm.root
+	for current != nil {
+		if tm.compare(key, current.Key) >= 0 {
+			current = current.Right
+		} else {
+			result = current
+			current = current.Left
+		}
+	}
+	return result
+}
+
+// poll 删除节点并返回其键值对
+func (tm *TreeMap[K, V]) poll(node *TreeNode[K, V]) (K, V, bool) {
+	key, value, ok := entryOf(node)
+	if ok {
+		tm.deleteNode(node)
+	}
+	return key, value, ok
+}
+
+// insertFixup 插入红色节点后通过变色和旋转恢复红黑树性质
+func (tm *TreeMap[K, V]) insertFixup(node *TreeNode[K, V]) {
+	for isRed(node.Parent) {
+		parent := node.Parent
+		grandparent := parent.Parent // 父节点为红色，一定不是根节点
+
+		if parent == grandparent.Left {
+			uncle := grandparent.Right
+			if isRed(uncle) {
+				// 叔节点为红色：父、叔变黑，祖父变红，继续向上检查
+				parent.Color = black
+				uncle.Color = black
+				grandparent.Color = red
+				node = grandparent
+				continue
+			}
+			if node == parent.Right {
+				// 内侧插入先转为外侧
+				node = parent
+				tm.rotateLeft(node)
+				parent = node.Parent
+			}
+			parent.Color = black
+			grandparent.Color = red
+			tm.rotateRight(grandparent)
+		} else {
+			uncle := grandparent.Left
+			if isRed(uncle) {
+				parent.Color = black
+				uncle.Color = black
+				grandparent.Color = red
+				node = grandparent
+				continue
+			}
+			if node == parent.Left {
+				node = parent
+				tm.rotateRight(node)
+				parent = node.Parent
+			}
+			parent.Color = black
+			grandparent.Color = red
+			tm.rotateLeft(grandparent)
+		}
+	}
+	tm.root.Color = black
+}
+
+// deleteNode 删除节点并恢复红黑树性质
+func (tm *TreeMap[K, V]) deleteNode(node *TreeNode[K, V]) {
+	if node.Left != nil && node.Right != nil {
+		// 有两个子节点，用后继节点的内容替换后删除后继节点
+		s := minNode(node.Right)
+		node.Key = s.Key
+		node.Value = s.Value
+		node = s
+	}
+
+	// 此时node最多只有一个子节点
+	child := node.Left
+	if child == nil {
+		child = node.Right
+	}
+	parent := node.Parent
+	if child != nil {
+		child.Parent = parent
+	}
+	tm.replaceChild(parent, node, child)
+	for p := parent; p != nil; p = p.Parent {
+		p.size--
+	}
+	tm.length--
+
+	if node.Color == black {
+		tm.deleteFixup(child, parent)
+	}
+	node.Left, node.Right, node.Parent = nil, nil, nil
+}
+
+// deleteFixup 删除黑色节点后恢复红黑树性质
+// node 为顶替被删除节点的子节点（可能为nil），parent为其父节点
+func (tm *TreeMap[K, V]) deleteFixup(node, parent *TreeNode[K, V]) {
+	for node != tm.root && !isRed(node) {
+		if node == parent.Left {
+			sibling := parent.Right
+			if isRed(sibling) {
+				// 兄弟节点为红色：转换为兄弟节点为黑色的情况
+				sibling.Color = black
+				parent.Color = red
+				tm.rotateLeft(parent)
+				sibling = parent.Right
 			}
-			return true
-		} else if node.Left == nil {
-			// 只有右子节点
-			if node.Parent != nil {
-				if node.Parent.Left == node {
-					node.Parent.Left = node.Right
-				} else {
-					node.Parent.Right = node.Right
-				}
+			if !isRed(sibling.Left) && !isRed(sibling.Right) {
+				// 兄弟节点的子节点都为黑色：兄弟变红，问题上移
+				sibling.Color = red
+				node = parent
+				parent = node.Parent
+				continue
 			}
-			node.Right.Parent = node.Parent
-			return true
-		} else if node.Right == nil {
-			// 只有左子节点
-			if node.Parent != nil {
-				if node.Parent.Left == node {
-					node.Parent.Left = node.Left
-				} else {
-					node.Parent.Right = node.Left
-				}
+			if !isRed(sibling.Right) {
+				// 兄弟节点的近侧子节点为红色：转为远侧子节点为红色
+				sibling.Left.Color = black
+				sibling.Color = red
+				tm.rotateRight(sibling)
+				sibling = parent.Right
 			}
-			node.Left.Parent = node.Parent
-			return true
+			sibling.Color = parent.Color
+			parent.Color = black
+			sibling.Right.Color = black
+			tm.rotateLeft(parent)
+			node = tm.root
 		} else {
-			// 有两个子节点，找到后继节点
-			successor := tm.min(node.Right)
-			node.Key = successor.Key
-			node.Value = successor.Value
-			return tm.delete(node.Right, successor.Key)
+			sibling := parent.Left
+			if isRed(sibling) {
+				sibling.Color = black
+				parent.Color = red
+				tm.rotateRight(parent)
+				sibling = parent.Left
+			}
+			if !isRed(sibling.Left) && !isRed(sibling.Right) {
+				sibling.Color = red
+				node = parent
+				parent = node.Parent
+				continue
+			}
+			if !isRed(sibling.Left) {
+				sibling.Right.Color = black
+				sibling.Color = red
+				tm.rotateLeft(sibling)
+				sibling = parent.Left
+			}
+			sibling.Color = parent.Color
+			parent.Color = black
+			sibling.Left.Color = black
+			tm.rotateRight(parent)
+			node = tm.root
 		}
 	}
+	if node != nil {
+		node.Color = black
+	}
 }
 
-// min 找到最小节点
-func (tm *TreeMap[K, V]) min(node *TreeNode[K, V]) *TreeNode[K, V] {
-	current := node
-	for current.Left != nil {
-		current = current.Left
+// rotateLeft 以node为支点左旋
+func (tm *TreeMap[K, V]) rotateLeft(node *TreeNode[K, V]) {
+	right := node.Right
+	node.Right = right.Left
+	if right.Left != nil {
+		right.Left.Parent = node
 	}
-	return current
+	right.Parent = node.Parent
+	tm.replaceChild(node.Parent, node, right)
+	right.Left = node
+	node.Parent = right
+
+	right.size = node.size
+	node.size = nodeSize(node.Left) + nodeSize(node.Right) + 1
 }
 
-// max 找到最大节点
-func (tm *TreeMap[K, V]) max(node *TreeNode[K, V]) *TreeNode[K, V] {
-	current := node
-	for current.Right != nil {
-		current = current.Right
+// rotateRight 以node为支点右旋
+func (tm *TreeMap[K, V]) rotateRight(node *TreeNode[K, V]) {
+	left := node.Left
+	node.Left = left.Right
+	if left.Right != nil {
+		left.Right.Parent = node
 	}
-	return current
+	left.Parent = node.Parent
+	tm.replaceChild(node.Parent, node, left)
+	left.Right = node
+	node.Parent = left
+
+	left.size = node.size
+	node.size = nodeSize(node.Left) + nodeSize(node.Right) + 1
 }
 
-// inOrderTraversal 中序遍历收集键
-func (tm *TreeMap[K, V]) inOrderTraversal(node *TreeNode[K, V], keys *[]K) {
-	if node != nil {
-		tm.inOrderTraversal(node.Left, keys)
-		*keys = append(*keys, node.Key)
-		tm.inOrderTraversal(node.Right, keys)
+// replaceChild 将parent中指向oldChild的指针替换为newChild，parent为nil时替换根节点
+func (tm *TreeMap[K, V]) replaceChild(parent, oldChild, newChild *TreeNode[K, V]) {
+	switch {
+	case parent == nil:
+		tm.root = newChild
+	case parent.Left == oldChild:
+		parent.Left = newChild
+	default:
+		parent.Right = newChild
 	}
 }
 
-// inOrderTraversalValues 中序遍历收集值
-func (tm *TreeMap[K, V]) inOrderTraversalValues(node *TreeNode[K, V], values *[]V) {
-	if node != nil {
-		tm.inOrderTraversalValues(node.Left, values)
-		*values = append(*values, node.Value)
-		tm.inOrderTraversalValues(node.Right, values)
+// entryOf 返回节点的键值对，node为nil时返回false
+func entryOf[K, V any](node *TreeNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
 	}
+	return node.Key, node.Value, true
 }
 
-// inOrderTraversalEntries 中序遍历收集键值对
-func (tm *TreeMap[K, V]) inOrderTraversalEntries(node *TreeNode[K, V], entries *[]struct {
-	K K
-	V V
-},
-) {
-	if node != nil {
-		tm.inOrderTraversalEntries(node.Left, entries)
-		*entries = append(*entries, struct {
-			K K
-			V V
-		}{K: node.Key, V: node.Value})
-		tm.inOrderTraversalEntries(node.Right, entries)
+// nodeSize 返回子树的节点数，nil节点为0
+func nodeSize[K, V any](node *TreeNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// isRed 判断节点是否为红色，nil节点视为黑色
+func isRed[K, V any](node *TreeNode[K, V]) bool {
+	return node != nil && node.Color == red
+}
+
+// minNode 返回以node为根的子树中的最小节点
+func minNode[K, V any](node *TreeNode[K, V]) *TreeNode[K, V] {
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+// maxNode 返回以node为根的子树中的最大节点
+func maxNode[K, V any](node *TreeNode[K, V]) *TreeNode[K, V] {
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+// successor 返回中序遍历中的下一个节点
+func successor[K, V any](node *TreeNode[K, V]) *TreeNode[K, V] {
+	if node.Right != nil {
+		return minNode(node.Right)
+	}
+	parent := node.Parent
+	for parent != nil && node == parent.Right {
+		node = parent
+		parent = parent.Parent
+	}
+	return parent
+}
+
+// predecessor 返回中序遍历中的上一个节点
+func predecessor[K, V any](node *TreeNode[K, V]) *TreeNode[K, V] {
+	if node.Left != nil {
+		return maxNode(node.Left)
+	}
+	parent := node.Parent
+	for parent != nil && node == parent.Left {
+		node = parent
+		parent = parent.Parent
 	}
+	return parent
 }
@@ -1,7 +1,16 @@
 package mapx
 
 import (
+	"cmp"
+	"maps"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -149,4 +158,482 @@ func TestTreeMap(t *testing.T) {
 		val, _ := tm.Get(1)
 		assert.Equal(t, "ONE", val)
 	})
+
+	t.Run("删除根节点", func(t *testing.T) {
+		tm := NewTreeMap[int, string]()
+		tm.Put(1, "one")
+		tm.Remove(1)
+		assert.True(t, tm.IsEmpty())
+		assert.False(t, tm.Contains(1))
+		assert.Empty(t, tm.Keys())
+
+		tm.Put(2, "two")
+		assert.Equal(t, []int{2}, tm.Keys())
+		checkRedBlack(t, tm)
+	})
+
+	t.Run("顺序插入保持平衡", func(t *testing.T) {
+		tm := NewTreeMap[int, int]()
+		const n = 10000
+		for i := 0; i < n; i++ {
+			tm.Put(i, i)
+		}
+		checkRedBlack(t, tm)
+		// 红黑树高度不超过2*log2(n+1)
+		assert.LessOrEqual(t, treeHeight(tm.root), 2*bits.Len(n+1))
+
+		for i := 0; i < n; i += 2 {
+			tm.Remove(i)
+		}
+		checkRedBlack(t, tm)
+		assert.Equal(t, n/2, tm.Size())
+		assert.LessOrEqual(t, treeHeight(tm.root), 2*bits.Len(n/2+1))
+	})
+
+	t.Run("随机操作", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		tm := NewTreeMap[int, int]()
+		model := make(map[int]int)
+
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				tm.Remove(key)
+				delete(model, key)
+			} else {
+				tm.Put(key, i)
+				model[key] = i
+			}
+			if i%100 == 0 {
+				checkRedBlack(t, tm)
+			}
+		}
+		checkRedBlack(t, tm)
+		assertMatchesModel(t, tm, model)
+	})
+}
+
+func TestTreeMapNavigable(t *testing.T) {
+	newMap := func() *TreeMap[int, string] {
+		tm := NewTreeMap[int, string]()
+		for _, k := range []int{10, 20, 30, 40, 50} {
+			tm.Put(k, strconv.Itoa(k))
+		}
+		return tm
+	}
+
+	t.Run("Floor、Ceiling、Lower和Higher", func(t *testing.T) {
+		tm := newMap()
+
+		cases := []struct {
+			name  string
+			fn    func(int) (int, string, bool)
+			key   int
+			want  int
+			found bool
+		}{
+			{"Floor命中", tm.Floor, 30, 30, true},
+			{"Floor介于之间", tm.Floor, 35, 30, true},
+			{"Floor小于最小值", tm.Floor, 5, 0, false},
+			{"Ceiling命中", tm.Ceiling, 30, 30, true},
+			{"Ceiling介于之间", tm.Ceiling, 35, 40, true},
+			{"Ceiling大于最大值", tm.Ceiling, 55, 0, false},
+			{"Lower命中时取前一个", tm.Lower, 30, 20, true},
+			{"Lower最小值", tm.Lower, 10, 0, false},
+			{"Higher命中时取后一个", tm.Higher, 30, 40, true},
+			{"Higher最大值", tm.Higher, 50, 0, false},
+		}
+		for _, c := range cases {
+			key, val, ok := c.fn(c.key)
+			assert.Equal(t, c.found, ok, c.name)
+			assert.Equal(t, c.want, key, c.name)
+			if ok {
+				assert.Equal(t, strconv.Itoa(c.want), val, c.name)
+			}
+		}
+	})
+
+	t.Run("First、Last、PollFirst和PollLast", func(t *testing.T) {
+		tm := newMap()
+
+		key, _, ok := tm.First()
+		assert.True(t, ok)
+		assert.Equal(t, 10, key)
+		key, _, ok = tm.Last()
+		assert.True(t, ok)
+		assert.Equal(t, 50, key)
+
+		key, val, ok := tm.PollFirst()
+		assert.True(t, ok)
+		assert.Equal(t, 10, key)
+		assert.Equal(t, "10", val)
+		key, _, _ = tm.PollLast()
+		assert.Equal(t, 50, key)
+		assert.Equal(t, []int{20, 30, 40}, tm.Keys())
+		checkRedBlack(t, tm)
+
+		empty := NewTreeMap[int, string]()
+		_, _, ok = empty.PollFirst()
+		assert.False(t, ok)
+		_, _, ok = empty.PollLast()
+		assert.False(t, ok)
+		_, _, ok = empty.First()
+		assert.False(t, ok)
+	})
+
+	t.Run("SubMap、HeadMap和TailMap", func(t *testing.T) {
+		tm := newMap()
+
+		assert.Equal(t, []int{20, 30, 40}, tm.SubMap(15, 50).Keys())
+		assert.Equal(t, []int{20, 30}, tm.SubMap(20, 40).Keys())
+		assert.Empty(t, tm.SubMap(40, 20).Keys())
+		assert.Equal(t, 0, tm.SubMap(40, 20).Size())
+		assert.Equal(t, []int{10, 20}, tm.HeadMap(30).Keys())
+		assert.Equal(t, []int{30, 40, 50}, tm.TailMap(30).Keys())
+		assert.Empty(t, tm.TailMap(60).Keys())
+		assert.True(t, tm.TailMap(60).IsEmpty())
+
+		sub := tm.SubMap(15, 45)
+		assert.Equal(t, 3, sub.Size())
+		assert.True(t, sub.Contains(20))
+		assert.False(t, sub.Contains(10))
+		_, ok := sub.Get(50)
+		assert.False(t, ok)
+		assert.Equal(t, []string{"20", "30", "40"}, sub.Values())
+
+		// 导航操作限制在视图范围内
+		key, _, _ := sub.First()
+		assert.Equal(t, 20, key)
+		key, _, _ = sub.Last()
+		assert.Equal(t, 40, key)
+		key, _, _ = sub.Floor(100)
+		assert.Equal(t, 40, key)
+		key, _, _ = sub.Ceiling(0)
+		assert.Equal(t, 20, key)
+		_, _, ok = sub.Lower(20)
+		assert.False(t, ok)
+		_, _, ok = sub.Higher(40)
+		assert.False(t, ok)
+
+		// 嵌套视图取两者的交集
+		assert.Equal(t, []int{30, 40}, sub.TailMap(25).Keys())
+		assert.Equal(t, []int{20, 30, 40}, sub.SubMap(0, 100).Keys())
+
+		// 视图不复制节点，原映射的修改对视图可见
+		tm.Put(25, "25")
+		tm.Remove(40)
+		assert.Equal(t, []int{20, 25, 30}, sub.Keys())
+		assert.Equal(t, 3, sub.Size())
+
+		// ToTreeMap复制出与原映射互不影响的TreeMap
+		copied := sub.ToTreeMap()
+		copied.Put(26, "26")
+		assert.Equal(t, []int{20, 25, 26, 30}, copied.Keys())
+		assert.Equal(t, []int{20, 25, 30}, sub.Keys())
+		checkRedBlack(t, copied)
+	})
+
+	t.Run("DescendingMap", func(t *testing.T) {
+		tm := newMap()
+		desc := tm.DescendingMap()
+
+		assert.Equal(t, []int{50, 40, 30, 20, 10}, desc.Keys())
+		key, _, _ := desc.First()
+		assert.Equal(t, 50, key)
+
+		// 逆序视图上的导航操作同样按逆序语义
+		key, _, _ = desc.Higher(30)
+		assert.Equal(t, 20, key)
+		key, _, _ = desc.Floor(35)
+		assert.Equal(t, 40, key)
+		assert.Equal(t, []int{40, 30}, desc.SubMap(45, 25).Keys())
+		assert.Equal(t, []int{50, 40}, desc.HeadMap(30).Keys())
+		assert.Equal(t, []int{30, 20, 10}, desc.TailMap(30).Keys())
+		assert.Equal(t, 3, desc.TailMap(30).Size())
+		assert.Equal(t, []int{10, 20}, desc.TailMap(30).DescendingMap().HeadMap(30).Keys())
+
+		var backward []int
+		for k := range desc.Backward() {
+			backward = append(backward, k)
+		}
+		assert.Equal(t, []int{10, 20, 30, 40, 50}, backward)
+
+		copied := desc.ToTreeMap()
+		copied.Put(35, "35")
+		assert.Equal(t, []int{50, 40, 35, 30, 20, 10}, copied.Keys())
+		assert.Equal(t, []int{10, 20, 30, 40, 50}, tm.Keys())
+		checkRedBlack(t, copied)
+	})
+
+	t.Run("Rank和Select", func(t *testing.T) {
+		tm := newMap()
+
+		assert.Equal(t, 0, tm.Rank(10))
+		assert.Equal(t, 2, tm.Rank(30))
+		assert.Equal(t, 3, tm.Rank(35))
+		assert.Equal(t, 0, tm.Rank(1))
+		assert.Equal(t, 5, tm.Rank(100))
+
+		for i, want := range []int{10, 20, 30, 40, 50} {
+			key, val, ok := tm.Select(i)
+			assert.True(t, ok)
+			assert.Equal(t, want, key)
+			assert.Equal(t, strconv.Itoa(want), val)
+		}
+		_, _, ok := tm.Select(-1)
+		assert.False(t, ok)
+		_, _, ok = tm.Select(5)
+		assert.False(t, ok)
+
+		tm.Remove(20)
+		assert.Equal(t, 1, tm.Rank(30))
+		key, _, _ := tm.Select(1)
+		assert.Equal(t, 30, key)
+	})
+}
+
+func TestTreeMapFunc(t *testing.T) {
+	t.Run("time.Time键", func(t *testing.T) {
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		tm := NewTreeMapFunc[time.Time, string](func(a, b time.Time) int {
+			return a.Compare(b)
+		})
+
+		tm.Put(base.Add(2*time.Hour), "c")
+		tm.Put(base, "a")
+		tm.Put(base.Add(time.Hour), "b")
+		assert.Equal(t, []string{"a", "b", "c"}, tm.Values())
+
+		key, val, ok := tm.Floor(base.Add(90 * time.Minute))
+		assert.True(t, ok)
+		assert.Equal(t, base.Add(time.Hour), key)
+		assert.Equal(t, "b", val)
+		checkRedBlack(t, tm)
+	})
+
+	t.Run("忽略大小写的字符串键", func(t *testing.T) {
+		tm := NewTreeMapFunc[string, int](func(a, b string) int {
+			return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+
+		tm.Put("Banana", 1)
+		tm.Put("apple", 2)
+		tm.Put("APPLE", 3)
+
+		assert.Equal(t, 2, tm.Size())
+		val, ok := tm.Get("Apple")
+		assert.True(t, ok)
+		assert.Equal(t, 3, val)
+		assert.Equal(t, []string{"apple", "Banana"}, tm.Keys())
+	})
+
+	t.Run("结构体键", func(t *testing.T) {
+		type version struct{ major, minor int }
+		tm := NewTreeMapFunc[version, string](func(a, b version) int {
+			return cmp.Or(cmp.Compare(a.major, b.major), cmp.Compare(a.minor, b.minor))
+		})
+
+		tm.Put(version{1, 10}, "1.10")
+		tm.Put(version{1, 2}, "1.2")
+		tm.Put(version{2, 0}, "2.0")
+		assert.Equal(t, []string{"1.2", "1.10", "2.0"}, tm.Values())
+		assert.Equal(t, 1, tm.Rank(version{1, 5}))
+	})
+
+	t.Run("比较函数为nil", func(t *testing.T) {
+		assert.Panics(t, func() { NewTreeMapFunc[int, int](nil) })
+	})
+}
+
+func TestTreeMapViewRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tm := NewTreeMap[int, int]()
+	for i := 0; i < 200; i++ {
+		k := r.Intn(100)
+		tm.Put(k, k)
+	}
+	keys := tm.Keys()
+
+	for i := 0; i < 200; i++ {
+		from, to := r.Intn(110)-5, r.Intn(110)-5
+		view := tm.SubMap(from, to)
+		// 逆序视图中[to, from)对应升序的(from, to]
+		desc := tm.DescendingMap().SubMap(to, from)
+
+		want, wantDesc := []int{}, []int{}
+		for _, k := range keys {
+			if k >= from && k < to {
+				want = append(want, k)
+			}
+			if k > from && k <= to {
+				wantDesc = append(wantDesc, k)
+			}
+		}
+		slices.Reverse(wantDesc)
+
+		assert.Equal(t, want, view.Keys())
+		assert.Equal(t, len(want), view.Size())
+		assert.Equal(t, wantDesc, desc.Keys())
+		assert.Equal(t, len(wantDesc), desc.Size())
+
+		probe := r.Intn(110) - 5
+		floor, _, ok := view.Floor(probe)
+		idx, found := slices.BinarySearch(want, probe)
+		switch {
+		case found:
+			assert.True(t, ok)
+			assert.Equal(t, probe, floor)
+		case idx > 0:
+			assert.True(t, ok)
+			assert.Equal(t, want[idx-1], floor)
+		default:
+			assert.False(t, ok)
+		}
+
+		// 逆序视图中Higher为按逆序严格排在probe之后，即升序中小于probe的最大键
+		higher, _, ok := desc.Higher(probe)
+		idx = sort.Search(len(wantDesc), func(j int) bool { return wantDesc[j] < probe })
+		if idx < len(wantDesc) {
+			assert.True(t, ok)
+			assert.Equal(t, wantDesc[idx], higher)
+		} else {
+			assert.False(t, ok)
+		}
+	}
+}
+
+func FuzzTreeMap(f *testing.F) {
+	f.Add([]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	f.Add([]byte{10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0x81, 0x83, 0x85})
+	f.Add([]byte{5, 5, 0x85, 0x85, 3, 7, 0x83, 1})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		tm := NewTreeMap[int, int]()
+		model := make(map[int]int)
+
+		// 最高位为1表示删除，低7位为键
+		for i, op := range ops {
+			key := int(op & 0x7f)
+			if op&0x80 != 0 {
+				tm.Remove(key)
+				delete(model, key)
+			} else {
+				tm.Put(key, i)
+				model[key] = i
+			}
+			checkRedBlack(t, tm)
+		}
+		assertMatchesModel(t, tm, model)
+	})
+}
+
+// checkRedBlack 检查红黑树的全部不变式：
+// 根节点为黑色、红色节点没有红色子节点、每条路径黑色节点数相同、父指针一致、键有序且数量正确
+func checkRedBlack[K, V any](t *testing.T, tm *TreeMap[K, V]) {
+	t.Helper()
+
+	if tm.root == nil {
+		assert.Equal(t, 0, tm.length)
+		return
+	}
+	assert.Nil(t, tm.root.Parent, "根节点的父节点必须为nil")
+	assert.False(t, isRed(tm.root), "根节点必须为黑色")
+
+	count := 0
+	var walk func(node *TreeNode[K, V]) int
+	walk = func(node *TreeNode[K, V]) int {
+		if node == nil {
+			return 1
+		}
+		count++
+
+		for _, child := range []*TreeNode[K, V]{node.Left, node.Right} {
+			if child == nil {
+				continue
+			}
+			assert.Same(t, node, child.Parent, "子节点的父指针不一致")
+			if isRed(node) {
+				assert.False(t, isRed(child), "红色节点不能有红色子节点")
+			}
+		}
+		if node.Left != nil {
+			assert.Negative(t, tm.compare(node.Left.Key, node.Key), "左子节点的键必须更小")
+		}
+		if node.Right != nil {
+			assert.Positive(t, tm.compare(node.Right.Key, node.Key), "右子节点的键必须更大")
+		}
+		assert.Equal(t, nodeSize(node.Left)+nodeSize(node.Right)+1, node.size, "子树大小不一致")
+
+		left, right := walk(node.Left), walk(node.Right)
+		assert.Equal(t, left, right, "左右子树的黑高不同")
+		if isRed(node) {
+			return left
+		}
+		return left + 1
+	}
+	walk(tm.root)
+
+	assert.Equal(t, tm.length, count)
+	assert.True(t, slices.IsSortedFunc(tm.Keys(), tm.compare))
+}
+
+// treeHeight 返回树高
+func treeHeight[K, V any](node *TreeNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return 1 + max(treeHeight(node.Left), treeHeight(node.Right))
+}
+
+// assertMatchesModel 检查TreeMap与map模型的内容一致
+func assertMatchesModel(t *testing.T, tm *TreeMap[int, int], model map[int]int) {
+	t.Helper()
+
+	assert.Equal(t, len(model), tm.Size())
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	assert.Equal(t, keys, tm.Keys())
+
+	for k, v := range model {
+		got, ok := tm.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, v, got)
+	}
+
+	for i, k := range keys {
+		assert.Equal(t, i, tm.Rank(k))
+		got, _, ok := tm.Select(i)
+		assert.True(t, ok)
+		assert.Equal(t, k, got)
+	}
+}
+
+func TestTreeMapIter(t *testing.T) {
+	tm := CollectTreeMap(maps.All(map[int]string{30: "c", 10: "a", 20: "b", 40: "d"}))
+
+	var keys []int
+	for k := range tm.All() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{10, 20, 30, 40}, keys)
+
+	keys = keys[:0]
+	for k := range tm.Backward() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{40, 30, 20, 10}, keys)
+
+	assert.Equal(t, map[int]string{20: "b", 30: "c"}, maps.Collect(tm.Range(15, 40)))
+	assert.Equal(t, map[int]string{10: "a"}, maps.Collect(tm.Range(10, 20)))
+	assert.Empty(t, maps.Collect(tm.Range(45, 50)))
+
+	for k := range tm.Range(0, 100) {
+		if k == 20 {
+			break
+		}
+		assert.Equal(t, 10, k)
+	}
 }
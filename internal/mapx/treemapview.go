@@ -0,0 +1,331 @@
+package mapx
+
+import "iter"
+
+// viewBound 视图一侧的边界，set为false表示该侧不限
+type viewBound[K any] struct {
+	key       K
+	set       bool
+	inclusive bool
+}
+
+// TreeMapView TreeMap中某个键范围的只读视图
+// 视图不复制节点，读取时直接遍历原映射，原映射的后续修改对视图可见
+// Size的时间复杂度为O(log n)，其余导航操作与TreeMap相同
+type TreeMapView[K, V any] struct {
+	tm   *TreeMap[K, V]
+	lo   viewBound[K] // 升序意义下的下界
+	hi   viewBound[K] // 升序意义下的上界
+	desc bool         // 是否按键逆序
+}
+
+// SubMap 返回键在[from, to)范围内的只读视图
+func (tm *TreeMap[K, V]) SubMap(from, to K) *TreeMapView[K, V] {
+	return tm.view().SubMap(from, to)
+}
+
+// HeadMap 返回键小于to的只读视图
+func (tm *TreeMap[K, V]) HeadMap(to K) *TreeMapView[K, V] {
+	return tm.view().HeadMap(to)
+}
+
+// TailMap 返回键大于等于from的只读视图
+func (tm *TreeMap[K, V]) TailMap(from K) *TreeMapView[K, V] {
+	return tm.view().TailMap(from)
+}
+
+// DescendingMap 返回按键逆序排列的只读视图
+func (tm *TreeMap[K, V]) DescendingMap() *TreeMapView[K, V] {
+	return tm.view().DescendingMap()
+}
+
+// view 返回覆盖整个映射的视图
+func (tm *TreeMap[K, V]) view() *TreeMapView[K, V] {
+	return &TreeMapView[K, V]{tm: tm}
+}
+
+// SubMap 返回视图中按视图顺序位于[from, to)之间的键组成的视图
+// 逆序视图中from应排在to之前，即from大于to
+func (v *TreeMapView[K, V]) SubMap(from, to K) *TreeMapView[K, V] {
+	if v.desc {
+		return v.narrow(viewBound[K]{key: to, set: true}, viewBound[K]{key: from, set: true, inclusive: true})
+	}
+	return v.narrow(viewBound[K]{key: from, set: true, inclusive: true}, viewBound[K]{key: to, set: true})
+}
+
+// HeadMap 返回视图中按视图顺序排在to之前的键组成的视图
+func (v *TreeMapView[K, V]) HeadMap(to K) *TreeMapView[K, V] {
+	if v.desc {
+		return v.narrow(viewBound[K]{key: to, set: true}, viewBound[K]{})
+	}
+	return v.narrow(viewBound[K]{}, viewBound[K]{key: to, set: true})
+}
+
+// TailMap 返回视图中按视图顺序从from开始的键组成的视图
+func (v *TreeMapView[K, V]) TailMap(from K) *TreeMapView[K, V] {
+	if v.desc {
+		return v.narrow(viewBound[K]{}, viewBound[K]{key: from, set: true, inclusive: true})
+	}
+	return v.narrow(viewBound[K]{key: from, set: true, inclusive: true}, viewBound[K]{})
+}
+
+// DescendingMap 返回顺序相反的视图
+func (v *TreeMapView[K, V]) DescendingMap() *TreeMapView[K, V] {
+	return &TreeMapView[K, V]{tm: v.tm, lo: v.lo, hi: v.hi, desc: !v.desc}
+}
+
+// Get 获取值，键不在视图范围内时返回false
+func (v *TreeMapView[K, V]) Get(key K) (V, bool) {
+	if !v.inRange(key) {
+		var zero V
+		return zero, false
+	}
+	return v.tm.Get(key)
+}
+
+// Contains 检查键是否在视图中
+func (v *TreeMapView[K, V]) Contains(key K) bool {
+	return v.inRange(key) && v.tm.Contains(key)
+}
+
+// Size 返回视图中的键值对数量
+func (v *TreeMapView[K, V]) Size() int {
+	below := 0 // 小于下界的键数量
+	if v.lo.set {
+		below = v.tm.Rank(v.lo.key)
+		if !v.lo.inclusive && v.tm.Contains(v.lo.key) {
+			below++
+		}
+	}
+	upto := v.tm.length // 不超过上界的键数量
+	if v.hi.set {
+		upto = v.tm.Rank(v.hi.key)
+		if v.hi.inclusive && v.tm.Contains(v.hi.key) {
+			upto++
+		}
+	}
+	return max(upto-below, 0)
+}
+
+// IsEmpty 检查视图是否为空
+func (v *TreeMapView[K, V]) IsEmpty() bool {
+	return v.lowest() == nil
+}
+
+// Keys 按视图顺序返回所有键
+func (v *TreeMapView[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	for key := range v.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values 按视图顺序返回所有值
+func (v *TreeMapView[K, V]) Values() []V {
+	values := make([]V, 0)
+	for _, value := range v.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// All 返回按视图顺序遍历键值对的迭代器
+func (v *TreeMapView[K, V]) All() iter.Seq2[K, V] {
+	if v.desc {
+		return v.descending()
+	}
+	return v.ascending()
+}
+
+// Backward 返回按视图逆序遍历键值对的迭代器
+func (v *TreeMapView[K, V]) Backward() iter.Seq2[K, V] {
+	if v.desc {
+		return v.ascending()
+	}
+	return v.descending()
+}
+
+// First 返回视图中的第一个键值对
+func (v *TreeMapView[K, V]) First() (K, V, bool) {
+	if v.desc {
+		return entryOf(v.highest())
+	}
+	return entryOf(v.lowest())
+}
+
+// Last 返回视图中的最后一个键值对
+func (v *TreeMapView[K, V]) Last() (K, V, bool) {
+	if v.desc {
+		return entryOf(v.lowest())
+	}
+	return entryOf(v.highest())
+}
+
+// Floor 返回按视图顺序不晚于key的最后一个键值对
+func (v *TreeMapView[K, V]) Floor(key K) (K, V, bool) {
+	if v.desc {
+		return entryOf(v.clampUp(v.tm.ceilingNode(key)))
+	}
+	return entryOf(v.clampDown(v.tm.floorNode(key)))
+}
+
+// Ceiling 返回按视图顺序不早于key的第一个键值对
+func (v *TreeMapView[K, V]) Ceiling(key K) (K, V, bool) {
+	if v.desc {
+		return entryOf(v.clampDown(v.tm.floorNode(key)))
+	}
+	return entryOf(v.clampUp(v.tm.ceilingNode(key)))
+}
+
+// Lower 返回按视图顺序严格早于key的最后一个键值对
+func (v *TreeMapView[K, V]) Lower(key K) (K, V, bool) {
+	if v.desc {
+		return entryOf(v.clampUp(v.tm.higherNode(key)))
+	}
+	return entryOf(v.clampDown(v.tm.lowerNode(key)))
+}
+
+// Higher 返回按视图顺序严格晚于key的第一个键值对
+func (v *TreeMapView[K, V]) Higher(key K) (K, V, bool) {
+	if v.desc {
+		return entryOf(v.clampDown(v.tm.lowerNode(key)))
+	}
+	return entryOf(v.clampUp(v.tm.higherNode(key)))
+}
+
+// ToTreeMap 将视图中的键值对复制为新的TreeMap，逆序视图复制后同样按逆序排列
+func (v *TreeMapView[K, V]) ToTreeMap() *TreeMap[K, V] {
+	compare := v.tm.compare
+	if v.desc {
+		compare = func(a, b K) int { return v.tm.compare(b, a) }
+	}
+	result := &TreeMap[K, V]{compare: compare}
+	for key, value := range v.All() {
+		result.Put(key, value)
+	}
+	return result
+}
+
+// narrow 返回同时满足当前边界和给定升序边界的视图
+func (v *TreeMapView[K, V]) narrow(lo, hi viewBound[K]) *TreeMapView[K, V] {
+	result := &TreeMapView[K, V]{tm: v.tm, lo: v.lo, hi: v.hi, desc: v.desc}
+	if lo.set {
+		if c := v.compareBound(lo, v.lo); !v.lo.set || c > 0 || (c == 0 && !lo.inclusive) {
+			result.lo = lo
+		}
+	}
+	if hi.set {
+		if c := v.compareBound(hi, v.hi); !v.hi.set || c < 0 || (c == 0 && !hi.inclusive) {
+			result.hi = hi
+		}
+	}
+	return result
+}
+
+// compareBound 比较两个边界的键，b未设置时返回0
+func (v *TreeMapView[K, V]) compareBound(a, b viewBound[K]) int {
+	if !b.set {
+		return 0
+	}
+	return v.tm.compare(a.key, b.key)
+}
+
+// tooLow 检查键是否小于下界
+func (v *TreeMapView[K, V]) tooLow(key K) bool {
+	if !v.lo.set {
+		return false
+	}
+	c := v.tm.compare(key, v.lo.key)
+	return c < 0 || (c == 0 && !v.lo.inclusive)
+}
+
+// tooHigh 检查键是否大于上界
+func (v *TreeMapView[K, V]) tooHigh(key K) bool {
+	if !v.hi.set {
+		return false
+	}
+	c := v.tm.compare(key, v.hi.key)
+	return c > 0 || (c == 0 && !v.hi.inclusive)
+}
+
+// inRange 检查键是否在视图范围内
+func (v *TreeMapView[K, V]) inRange(key K) bool {
+	return !v.tooLow(key) && !v.tooHigh(key)
+}
+
+// lowest 返回视图范围内键最小的节点
+func (v *TreeMapView[K, V]) lowest() *TreeNode[K, V] {
+	node := v.tm.first()
+	if v.lo.set {
+		if v.lo.inclusive {
+			node = v.tm.ceilingNode(v.lo.key)
+		} else {
+			node = v.tm.higherNode(v.lo.key)
+		}
+	}
+	if node == nil || v.tooHigh(node.Key) {
+		return nil
+	}
+	return node
+}
+
+// highest 返回视图范围内键最大的节点
+func (v *TreeMapView[K, V]) highest() *TreeNode[K, V] {
+	node := v.tm.last()
+	if v.hi.set {
+		if v.hi.inclusive {
+			node = v.tm.floorNode(v.hi.key)
+		} else {
+			node = v.tm.lowerNode(v.hi.key)
+		}
+	}
+	if node == nil || v.tooLow(node.Key) {
+		return nil
+	}
+	return node
+}
+
+// clampDown 将不超过某个键的候选节点限制到视图范围内，候选节点超过上界时改用最大节点
+func (v *TreeMapView[K, V]) clampDown(node *TreeNode[K, V]) *TreeNode[K, V] {
+	if node == nil || v.tooLow(node.Key) {
+		return nil
+	}
+	if v.tooHigh(node.Key) {
+		return v.highest()
+	}
+	return node
+}
+
+// clampUp 将不小于某个键的候选节点限制到视图范围内，候选节点低于下界时改用最小节点
+func (v *TreeMapView[K, V]) clampUp(node *TreeNode[K, V]) *TreeNode[K, V] {
+	if node == nil || v.tooHigh(node.Key) {
+		return nil
+	}
+	if v.tooLow(node.Key) {
+		return v.lowest()
+	}
+	return node
+}
+
+// ascending 按键升序遍历视图范围内的节点
+func (v *TreeMapView[K, V]) ascending() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := v.lowest(); node != nil && !v.tooHigh(node.Key); node = successor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// descending 按键降序遍历视图范围内的节点
+func (v *TreeMapView[K, V]) descending() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := v.highest(); node != nil && !v.tooLow(node.Key); node = predecessor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
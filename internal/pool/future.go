@@ -0,0 +1,70 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrTaskPanicked 任务执行时发生panic
+var ErrTaskPanicked = errors.New("task panicked")
+
+// Future 异步任务的执行结果
+type Future[R any] struct {
+	done  chan struct{}
+	value R
+	err   error
+}
+
+// SubmitFunc 提交有返回值的任务，返回对应的Future
+// 任务panic时Future返回ErrTaskPanicked，panic仍会交给协程池的PanicHandler处理
+func SubmitFunc[R any](p *Pool, task func() (R, error)) (*Future[R], error) {
+	f := &Future[R]{done: make(chan struct{})}
+	err := p.Submit(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				f.err = fmt.Errorf("%w: %v", ErrTaskPanicked, r)
+				close(f.done)
+				panic(r)
+			}
+		}()
+		f.value, f.err = task()
+		close(f.done)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Get 阻塞等待任务完成并返回结果
+func (f *Future[R]) Get() (R, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// GetContext 等待任务完成并返回结果，ctx结束时返回ctx的错误
+func (f *Future[R]) GetContext(ctx context.Context) (R, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero R
+		return zero, ctx.Err()
+	}
+}
+
+// Done 返回任务完成时关闭的通道
+func (f *Future[R]) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsDone 检查任务是否已完成
+func (f *Future[R]) IsDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,333 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sword-demon/vtool/internal/queue"
+)
+
+var (
+	// ErrPoolClosed 协程池已关闭
+	ErrPoolClosed = errors.New("pool is closed")
+	// ErrPoolOverload 等待队列已满
+	ErrPoolOverload = errors.New("pool is overloaded")
+)
+
+// Options 协程池选项
+type Options struct {
+	// MinWorkers 回收空闲协程时保留的最少协程数
+	// 协程按需创建，不会预先启动；已创建的协程数不超过该值时不会被回收
+	MinWorkers int
+	// ExpiryDuration 空闲协程的过期时间，0表示不回收（固定大小的协程池）
+	ExpiryDuration time.Duration
+	// MaxBacklog 等待队列上限，0表示不限
+	MaxBacklog int
+	// PanicHandler 任务panic时的处理函数，为nil时仅计数
+	PanicHandler func(any)
+}
+
+// Stats 协程池运行时统计
+type Stats struct {
+	Capacity  int    // 最大协程数
+	Workers   int    // 当前存活的协程数
+	Running   int    // 正在执行任务的协程数
+	Idle      int    // 空闲的协程数
+	Waiting   int    // 等待执行的任务数
+	Completed uint64 // 已执行完成的任务数（包括panic的任务）
+	Panicked  uint64 // 发生panic的任务数
+}
+
+// worker 工作协程
+type worker struct {
+	tasks    chan func()
+	lastUsed time.Time
+}
+
+// Pool 协程池
+// 任务优先交给空闲协程执行，没有空闲协程且未达到容量时创建新协程，
+// 否则进入等待队列；带优先级的任务会先于普通任务被执行
+type Pool struct {
+	mu       sync.Mutex
+	opts     Options
+	capacity int
+	workers  int
+	running  int
+	idle     []*worker // 按空闲开始时间排序，末尾为最近空闲的协程
+	backlog  *queue.Queue[func()]
+	priority *queue.PriorityQueue[func()]
+	closed   bool
+	stop     chan struct{}
+	wg       sync.WaitGroup
+
+	completed atomic.Uint64
+	panicked  atomic.Uint64
+}
+
+// NewPool 创建新的协程池
+// size 为最大协程数，小于1时按1处理
+func NewPool(size int, opts ...Options) *Pool {
+	if size < 1 {
+		size = 1
+	}
+
+	var options Options
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.MinWorkers < 0 {
+		options.MinWorkers = 0
+	}
+	if options.MinWorkers > size {
+		options.MinWorkers = size
+	}
+
+	p := &Pool{
+		opts:     options,
+		capacity: size,
+		backlog:  queue.NewQueue[func()](),
+		priority: queue.NewPriorityQueue[func()](),
+		stop:     make(chan struct{}),
+	}
+
+	if options.ExpiryDuration > 0 {
+		go p.purge()
+	}
+	return p
+}
+
+// Submit 提交任务
+func (p *Pool) Submit(task func()) error {
+	return p.submit(task, 0, false)
+}
+
+// SubmitPriority 提交带优先级的任务，数值越小优先级越高
+// 等待执行时带优先级的任务总是先于普通任务
+func (p *Pool) SubmitPriority(task func(), priority int) error {
+	return p.submit(task, priority, true)
+}
+
+// SubmitWait 提交任务并等待其执行完成
+// 任务panic时返回ErrTaskPanicked，panic仍会交给协程池的PanicHandler处理
+func (p *Pool) SubmitWait(task func()) error {
+	done := make(chan struct{})
+	var taskErr error
+	err := p.Submit(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				taskErr = fmt.Errorf("%w: %v", ErrTaskPanicked, r)
+				close(done)
+				panic(r)
+			}
+		}()
+		task()
+		close(done)
+	})
+	if err != nil {
+		return err
+	}
+	<-done
+	return taskErr
+}
+
+// Shutdown 关闭协程池
+// 不再接收新任务，等待已提交的任务全部执行完成，或ctx结束
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.stop)
+		for _, w := range p.idle {
+			w.tasks <- nil
+		}
+		p.workers -= len(p.idle)
+		p.idle = nil
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsClosed 检查协程池是否已关闭
+func (p *Pool) IsClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// Cap 返回最大协程数
+func (p *Pool) Cap() int {
+	return p.capacity
+}
+
+// Stats 返回运行时统计
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Stats{
+		Capacity:  p.capacity,
+		Workers:   p.workers,
+		Running:   p.running,
+		Idle:      len(p.idle),
+		Waiting:   p.waiting(),
+		Completed: p.completed.Load(),
+		Panicked:  p.panicked.Load(),
+	}
+}
+
+// submit 提交任务（内部方法）
+func (p *Pool) submit(task func(), priority int, hasPriority bool) error {
+	if task == nil {
+		return errors.New("task cannot be nil")
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+
+	// 优先使用最近空闲的协程
+	if n := len(p.idle); n > 0 {
+		w := p.idle[n-1]
+		p.idle[n-1] = nil
+		p.idle = p.idle[:n-1]
+		p.running++
+		p.mu.Unlock()
+		w.tasks <- task
+		return nil
+	}
+
+	// 未达到容量，创建新协程
+	if p.workers < p.capacity {
+		p.workers++
+		p.running++
+		p.wg.Add(1)
+		p.mu.Unlock()
+		go p.run(&worker{tasks: make(chan func(), 1)}, task)
+		return nil
+	}
+
+	// 进入等待队列
+	if p.opts.MaxBacklog > 0 && p.waiting() >= p.opts.MaxBacklog {
+		p.mu.Unlock()
+		return ErrPoolOverload
+	}
+	if hasPriority {
+		p.priority.Enqueue(task, priority)
+	} else {
+		p.backlog.Enqueue(task)
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+// run 工作协程主循环
+func (p *Pool) run(w *worker, task func()) {
+	defer p.wg.Done()
+	for task != nil {
+		p.execute(task)
+		task = p.next(w)
+	}
+}
+
+// execute 执行任务并恢复panic
+func (p *Pool) execute(task func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.panicked.Add(1)
+			if p.opts.PanicHandler != nil {
+				p.opts.PanicHandler(r)
+			}
+		}
+		p.completed.Add(1)
+	}()
+	task()
+}
+
+// next 获取下一个任务，返回nil表示协程应当退出
+func (p *Pool) next(w *worker) func() {
+	p.mu.Lock()
+	p.running--
+
+	if task, ok := p.dequeue(); ok {
+		p.running++
+		p.mu.Unlock()
+		return task
+	}
+
+	if p.closed {
+		p.workers--
+		p.mu.Unlock()
+		return nil
+	}
+
+	w.lastUsed = time.Now()
+	p.idle = append(p.idle, w)
+	p.mu.Unlock()
+	return <-w.tasks
+}
+
+// dequeue 从等待队列中取出任务，优先级任务优先（调用方需持有锁）
+func (p *Pool) dequeue() (func(), bool) {
+	if !p.priority.IsEmpty() {
+		task, _, _ := p.priority.Dequeue()
+		return task, true
+	}
+	if !p.backlog.IsEmpty() {
+		task, _ := p.backlog.Dequeue()
+		return task, true
+	}
+	return nil, false
+}
+
+// waiting 返回等待执行的任务数（调用方需持有锁）
+func (p *Pool) waiting() int {
+	return p.backlog.Size() + p.priority.Size()
+}
+
+// purge 定期回收过期的空闲协程
+func (p *Pool) purge() {
+	ticker := time.NewTicker(p.opts.ExpiryDuration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		deadline := time.Now().Add(-p.opts.ExpiryDuration)
+		expired := 0
+		for expired < len(p.idle) && p.workers-expired > p.opts.MinWorkers &&
+			!p.idle[expired].lastUsed.After(deadline) {
+			expired++
+		}
+		stale := make([]*worker, expired)
+		copy(stale, p.idle[:expired])
+		p.idle = append(p.idle[:0], p.idle[expired:]...)
+		p.workers -= expired
+		p.mu.Unlock()
+
+		for _, w := range stale {
+			w.tasks <- nil
+		}
+	}
+}
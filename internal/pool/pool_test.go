@@ -0,0 +1,216 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		p := NewPool(4)
+		assert.Equal(t, 4, p.Cap())
+
+		var count atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			err := p.Submit(func() {
+				defer wg.Done()
+				count.Add(1)
+			})
+			assert.NoError(t, err)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(100), count.Load())
+		assert.LessOrEqual(t, p.Stats().Workers, 4)
+		assert.NoError(t, p.Shutdown(context.Background()))
+		assert.Equal(t, uint64(100), p.Stats().Completed)
+	})
+
+	t.Run("SubmitWait", func(t *testing.T) {
+		p := NewPool(2)
+		defer p.Shutdown(context.Background())
+
+		done := false
+		err := p.SubmitWait(func() {
+			time.Sleep(5 * time.Millisecond)
+			done = true
+		})
+		assert.NoError(t, err)
+		assert.True(t, done)
+	})
+
+	t.Run("Future", func(t *testing.T) {
+		p := NewPool(2)
+		defer p.Shutdown(context.Background())
+
+		f, err := SubmitFunc(p, func() (int, error) {
+			return 42, nil
+		})
+		assert.NoError(t, err)
+		val, err := f.Get()
+		assert.NoError(t, err)
+		assert.Equal(t, 42, val)
+		assert.True(t, f.IsDone())
+
+		wantErr := errors.New("failed")
+		f, err = SubmitFunc(p, func() (int, error) {
+			return 0, wantErr
+		})
+		assert.NoError(t, err)
+		<-f.Done()
+		_, err = f.Get()
+		assert.ErrorIs(t, err, wantErr)
+
+		block := make(chan struct{})
+		f, err = SubmitFunc(p, func() (int, error) {
+			<-block
+			return 1, nil
+		})
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = f.GetContext(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		close(block)
+	})
+
+	t.Run("panic恢复", func(t *testing.T) {
+		var recovered atomic.Value
+		p := NewPool(1, Options{
+			PanicHandler: func(r any) {
+				recovered.Store(r)
+			},
+		})
+
+		err := p.SubmitWait(func() {
+			panic("boom")
+		})
+		assert.ErrorIs(t, err, ErrTaskPanicked)
+		assert.ErrorContains(t, err, "boom")
+		// PanicHandler在SubmitWait返回后才由工作协程调用
+		assert.Eventually(t, func() bool {
+			return recovered.Load() == "boom"
+		}, time.Second, time.Millisecond)
+
+		f, err := SubmitFunc(p, func() (int, error) {
+			panic("oops")
+		})
+		assert.NoError(t, err)
+		_, err = f.Get()
+		assert.ErrorIs(t, err, ErrTaskPanicked)
+
+		// 协程panic后仍可继续执行任务
+		ran := false
+		assert.NoError(t, p.SubmitWait(func() { ran = true }))
+		assert.True(t, ran)
+
+		assert.NoError(t, p.Shutdown(context.Background()))
+		assert.Equal(t, uint64(2), p.Stats().Panicked)
+	})
+
+	t.Run("优先级任务插队", func(t *testing.T) {
+		p := NewPool(1)
+
+		block := make(chan struct{})
+		assert.NoError(t, p.Submit(func() { <-block }))
+
+		var mu sync.Mutex
+		var order []string
+		record := func(name string) func() {
+			return func() {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+			}
+		}
+		assert.NoError(t, p.Submit(record("normal")))
+		assert.NoError(t, p.SubmitPriority(record("low"), 5))
+		assert.NoError(t, p.SubmitPriority(record("high"), 1))
+		assert.Equal(t, 3, p.Stats().Waiting)
+
+		close(block)
+		assert.NoError(t, p.Shutdown(context.Background()))
+		assert.Equal(t, []string{"high", "low", "normal"}, order)
+	})
+
+	t.Run("等待队列上限", func(t *testing.T) {
+		p := NewPool(1, Options{MaxBacklog: 1})
+
+		block := make(chan struct{})
+		assert.NoError(t, p.Submit(func() { <-block }))
+		assert.NoError(t, p.Submit(func() {}))
+		assert.ErrorIs(t, p.Submit(func() {}), ErrPoolOverload)
+
+		close(block)
+		assert.NoError(t, p.Shutdown(context.Background()))
+	})
+
+	t.Run("空闲协程过期", func(t *testing.T) {
+		p := NewPool(4, Options{
+			MinWorkers:     1,
+			ExpiryDuration: 10 * time.Millisecond,
+		})
+		defer p.Shutdown(context.Background())
+
+		var wg sync.WaitGroup
+		block := make(chan struct{})
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			assert.NoError(t, p.Submit(func() {
+				defer wg.Done()
+				<-block
+			}))
+		}
+		assert.Equal(t, 4, p.Stats().Workers)
+		close(block)
+		wg.Wait()
+
+		assert.Eventually(t, func() bool {
+			return p.Stats().Workers == 1
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Shutdown等待任务执行完毕", func(t *testing.T) {
+		p := NewPool(2)
+
+		var count atomic.Int32
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, p.Submit(func() {
+				time.Sleep(time.Millisecond)
+				count.Add(1)
+			}))
+		}
+
+		assert.NoError(t, p.Shutdown(context.Background()))
+		assert.Equal(t, int32(20), count.Load())
+		assert.True(t, p.IsClosed())
+		assert.ErrorIs(t, p.Submit(func() {}), ErrPoolClosed)
+
+		stats := p.Stats()
+		assert.Equal(t, 0, stats.Workers)
+		assert.Equal(t, 0, stats.Running)
+		assert.Equal(t, 0, stats.Waiting)
+	})
+
+	t.Run("Shutdown超时", func(t *testing.T) {
+		p := NewPool(1)
+
+		block := make(chan struct{})
+		assert.NoError(t, p.Submit(func() { <-block }))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, p.Shutdown(ctx), context.DeadlineExceeded)
+
+		close(block)
+		assert.NoError(t, p.Shutdown(context.Background()))
+	})
+}
@@ -0,0 +1,160 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// BlockingPriorityQueue 并发阻塞优先级队列 - 基于PriorityQueue实现
+// 队列为空时Dequeue阻塞，设置容量后队列满时Enqueue阻塞
+type BlockingPriorityQueue[T any] struct {
+	mu       sync.Mutex
+	items    *PriorityQueue[T]
+	capacity int
+	closed   bool
+	notEmpty notifier
+	notFull  notifier
+}
+
+// NewBlockingPriorityQueue 创建新的阻塞优先级队列
+// capacity 为队列容量，小于等于0时表示不限容量
+func NewBlockingPriorityQueue[T any](capacity int) *BlockingPriorityQueue[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &BlockingPriorityQueue[T]{
+		items:    NewPriorityQueue[T](),
+		capacity: capacity,
+	}
+}
+
+// Enqueue 入队 - 添加元素和优先级，队列已满时阻塞
+// 直到有空位、ctx结束或队列关闭
+func (pq *BlockingPriorityQueue[T]) Enqueue(ctx context.Context, value T, priority int) error {
+	pq.mu.Lock()
+	for {
+		if pq.closed {
+			pq.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if !pq.isFull() {
+			pq.items.Enqueue(value, priority)
+			pq.notEmpty.broadcast()
+			pq.mu.Unlock()
+			return nil
+		}
+
+		wait := pq.notFull.wait()
+		pq.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		pq.mu.Lock()
+	}
+}
+
+// Dequeue 出队 - 移除并返回优先级最高的元素，队列为空时阻塞
+// 直到有元素、ctx结束或队列关闭；关闭后仍可取出剩余元素
+func (pq *BlockingPriorityQueue[T]) Dequeue(ctx context.Context) (T, int, error) {
+	pq.mu.Lock()
+	for {
+		if !pq.items.IsEmpty() {
+			value, priority, _ := pq.items.Dequeue()
+			pq.notFull.broadcast()
+			pq.mu.Unlock()
+			return value, priority, nil
+		}
+		if pq.closed {
+			pq.mu.Unlock()
+			var zero T
+			return zero, 0, ErrQueueClosed
+		}
+
+		wait := pq.notEmpty.wait()
+		pq.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, 0, ctx.Err()
+		}
+		pq.mu.Lock()
+	}
+}
+
+// TryEnqueue 非阻塞入队，队列已满或已关闭时返回false
+func (pq *BlockingPriorityQueue[T]) TryEnqueue(value T, priority int) bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if pq.closed || pq.isFull() {
+		return false
+	}
+	pq.items.Enqueue(value, priority)
+	pq.notEmpty.broadcast()
+	return true
+}
+
+// TryDequeue 非阻塞出队，队列为空时返回false
+func (pq *BlockingPriorityQueue[T]) TryDequeue() (T, int, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	value, priority, err := pq.items.Dequeue()
+	if err != nil {
+		return value, priority, false
+	}
+	pq.notFull.broadcast()
+	return value, priority, true
+}
+
+// Peek 查看优先级最高的元素，不会阻塞
+func (pq *BlockingPriorityQueue[T]) Peek() (T, int, error) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.items.Peek()
+}
+
+// Close 关闭队列并唤醒所有等待的协程
+func (pq *BlockingPriorityQueue[T]) Close() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if pq.closed {
+		return
+	}
+	pq.closed = true
+	pq.notEmpty.broadcast()
+	pq.notFull.broadcast()
+}
+
+// IsClosed 检查队列是否已关闭
+func (pq *BlockingPriorityQueue[T]) IsClosed() bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.closed
+}
+
+// Size 返回队列中的元素数量
+func (pq *BlockingPriorityQueue[T]) Size() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.items.Size()
+}
+
+// IsEmpty 检查队列是否为空
+func (pq *BlockingPriorityQueue[T]) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// Capacity 返回队列容量，0表示不限容量
+func (pq *BlockingPriorityQueue[T]) Capacity() int {
+	return pq.capacity
+}
+
+// isFull 检查队列是否已满（调用方需持有锁）
+func (pq *BlockingPriorityQueue[T]) isFull() bool {
+	return pq.capacity > 0 && pq.items.Size() >= pq.capacity
+}
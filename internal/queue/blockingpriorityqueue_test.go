@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingPriorityQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("按优先级出队", func(t *testing.T) {
+		pq := NewBlockingPriorityQueue[string](0)
+
+		assert.NoError(t, pq.Enqueue(ctx, "low", 3))
+		assert.NoError(t, pq.Enqueue(ctx, "high", 1))
+		assert.NoError(t, pq.Enqueue(ctx, "mid", 2))
+		assert.Equal(t, 3, pq.Size())
+
+		val, priority, err := pq.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, "high", val)
+		assert.Equal(t, 1, priority)
+
+		for _, want := range []string{"high", "mid", "low"} {
+			val, _, err = pq.Dequeue(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, want, val)
+		}
+		assert.True(t, pq.IsEmpty())
+	})
+
+	t.Run("Dequeue阻塞直到有元素", func(t *testing.T) {
+		pq := NewBlockingPriorityQueue[int](0)
+
+		result := make(chan int)
+		go func() {
+			val, _, err := pq.Dequeue(ctx)
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		assert.True(t, pq.TryEnqueue(7, 1))
+		assert.Equal(t, 7, <-result)
+	})
+
+	t.Run("容量限制", func(t *testing.T) {
+		pq := NewBlockingPriorityQueue[int](2)
+		assert.Equal(t, 2, pq.Capacity())
+
+		assert.True(t, pq.TryEnqueue(1, 1))
+		assert.True(t, pq.TryEnqueue(2, 2))
+		assert.False(t, pq.TryEnqueue(3, 3))
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, pq.Enqueue(timeout, 3, 3), context.DeadlineExceeded)
+
+		done := make(chan struct{})
+		go func() {
+			assert.NoError(t, pq.Enqueue(ctx, 0, 0))
+			close(done)
+		}()
+
+		val, _, ok := pq.TryDequeue()
+		assert.True(t, ok)
+		assert.Equal(t, 1, val)
+		<-done
+
+		val, priority, err := pq.Dequeue(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, val)
+		assert.Equal(t, 0, priority)
+	})
+
+	t.Run("Dequeue取消", func(t *testing.T) {
+		pq := NewBlockingPriorityQueue[int](0)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		_, _, err := pq.Dequeue(cancelCtx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		pq := NewBlockingPriorityQueue[int](0)
+		assert.NoError(t, pq.Enqueue(ctx, 1, 1))
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				defer wg.Done()
+				_, _, _ = pq.Dequeue(ctx)
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		pq.Close()
+		wg.Wait()
+
+		assert.True(t, pq.IsClosed())
+		assert.ErrorIs(t, pq.Enqueue(ctx, 2, 2), ErrQueueClosed)
+		_, _, err := pq.Dequeue(ctx)
+		assert.ErrorIs(t, err, ErrQueueClosed)
+	})
+
+	t.Run("并发入队出队", func(t *testing.T) {
+		pq := NewBlockingPriorityQueue[int](8)
+		const total = 1000
+
+		go func() {
+			for i := 0; i < total; i++ {
+				assert.NoError(t, pq.Enqueue(ctx, i, i%10))
+			}
+		}()
+
+		count := 0
+		for count < total {
+			_, _, err := pq.Dequeue(ctx)
+			assert.NoError(t, err)
+			count++
+		}
+		assert.True(t, pq.IsEmpty())
+	})
+}
@@ -0,0 +1,205 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed 队列已关闭
+var ErrQueueClosed = errors.New("queue is closed")
+
+// notifier 广播通知器
+// 等待方通过wait获取通道，broadcast关闭通道以唤醒所有等待方
+// 调用方需持有外部锁
+type notifier struct {
+	ch chan struct{}
+}
+
+// wait 返回一个在下一次广播时关闭的通道
+func (n *notifier) wait() <-chan struct{} {
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	return n.ch
+}
+
+// broadcast 唤醒所有等待方
+func (n *notifier) broadcast() {
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
+
+// BlockingQueue 并发阻塞队列 - 基于Queue实现的有界FIFO队列
+// 队列满时Put阻塞，队列空时Take阻塞，可安全地在多个协程间共享
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	items    *Queue[T]
+	capacity int
+	closed   bool
+	notEmpty notifier
+	notFull  notifier
+}
+
+// NewBlockingQueue 创建新的阻塞队列
+// capacity 为队列容量，小于等于0时表示不限容量
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &BlockingQueue[T]{
+		items:    NewQueue[T](),
+		capacity: capacity,
+	}
+}
+
+// Put 入队 - 队列已满时阻塞直到有空位
+// 队列关闭后返回ErrQueueClosed
+func (q *BlockingQueue[T]) Put(value T) error {
+	return q.Offer(context.Background(), value)
+}
+
+// Take 出队 - 队列为空时阻塞直到有元素
+// 队列关闭后仍可取出剩余元素，取完后返回ErrQueueClosed
+func (q *BlockingQueue[T]) Take() (T, error) {
+	return q.Poll(context.Background())
+}
+
+// Offer 入队 - 队列已满时阻塞，直到有空位、ctx结束或队列关闭
+func (q *BlockingQueue[T]) Offer(ctx context.Context, value T) error {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if !q.isFull() {
+			q.items.Enqueue(value)
+			q.notEmpty.broadcast()
+			q.mu.Unlock()
+			return nil
+		}
+
+		wait := q.notFull.wait()
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		q.mu.Lock()
+	}
+}
+
+// Poll 出队 - 队列为空时阻塞，直到有元素、ctx结束或队列关闭
+func (q *BlockingQueue[T]) Poll(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for {
+		if !q.items.IsEmpty() {
+			value, _ := q.items.Dequeue()
+			q.notFull.broadcast()
+			q.mu.Unlock()
+			return value, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+
+		wait := q.notEmpty.wait()
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		q.mu.Lock()
+	}
+}
+
+// TryPut 非阻塞入队，队列已满或已关闭时返回false
+func (q *BlockingQueue[T]) TryPut(value T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.isFull() {
+		return false
+	}
+	q.items.Enqueue(value)
+	q.notEmpty.broadcast()
+	return true
+}
+
+// TryTake 非阻塞出队，队列为空时返回false
+func (q *BlockingQueue[T]) TryTake() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	value, err := q.items.Dequeue()
+	if err != nil {
+		return value, false
+	}
+	q.notFull.broadcast()
+	return value, true
+}
+
+// Peek 查看队首元素但不移除，不会阻塞
+func (q *BlockingQueue[T]) Peek() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Peek()
+}
+
+// Close 关闭队列并唤醒所有等待的协程
+// 关闭后不能再入队，但可以继续取出剩余元素
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.notEmpty.broadcast()
+	q.notFull.broadcast()
+}
+
+// IsClosed 检查队列是否已关闭
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Size 返回队列中的元素数量
+func (q *BlockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Size()
+}
+
+// IsEmpty 检查队列是否为空
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Capacity 返回队列容量，0表示不限容量
+func (q *BlockingQueue[T]) Capacity() int {
+	return q.capacity
+}
+
+// ToSlice 转换为切片
+func (q *BlockingQueue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.ToSlice()
+}
+
+// isFull 检查队列是否已满（调用方需持有锁）
+func (q *BlockingQueue[T]) isFull() bool {
+	return q.capacity > 0 && q.items.Size() >= q.capacity
+}
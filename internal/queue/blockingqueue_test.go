@@ -0,0 +1,195 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		q := NewBlockingQueue[int](3)
+
+		assert.True(t, q.IsEmpty())
+		assert.Equal(t, 3, q.Capacity())
+
+		assert.NoError(t, q.Put(1))
+		assert.NoError(t, q.Put(2))
+		assert.NoError(t, q.Put(3))
+		assert.Equal(t, 3, q.Size())
+		assert.Equal(t, []int{1, 2, 3}, q.ToSlice())
+
+		// 队列已满
+		assert.False(t, q.TryPut(4))
+
+		val, err := q.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+
+		val, err = q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+
+		val, ok := q.TryTake()
+		assert.True(t, ok)
+		assert.Equal(t, 2, val)
+
+		val, err = q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, val)
+
+		_, ok = q.TryTake()
+		assert.False(t, ok)
+	})
+
+	t.Run("不限容量", func(t *testing.T) {
+		q := NewBlockingQueue[int](0)
+
+		for i := 0; i < 100; i++ {
+			assert.True(t, q.TryPut(i))
+		}
+		assert.Equal(t, 100, q.Size())
+		assert.Equal(t, 0, q.Capacity())
+	})
+
+	t.Run("Take阻塞直到有元素", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+
+		result := make(chan int)
+		go func() {
+			val, err := q.Take()
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, q.Put(42))
+		assert.Equal(t, 42, <-result)
+	})
+
+	t.Run("Put阻塞直到有空位", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		assert.NoError(t, q.Put(1))
+
+		done := make(chan struct{})
+		go func() {
+			assert.NoError(t, q.Put(2))
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("Put should block when queue is full")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		val, err := q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+		<-done
+
+		val, err = q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, val)
+	})
+
+	t.Run("Offer和Poll超时", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := q.Poll(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		assert.NoError(t, q.Offer(context.Background(), 1))
+		ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel2()
+		err = q.Offer(ctx2, 2)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, q.Size())
+	})
+
+	t.Run("Close唤醒所有等待者", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := q.Take()
+				assert.ErrorIs(t, err, ErrQueueClosed)
+			}()
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+		wg.Wait()
+
+		assert.True(t, q.IsClosed())
+		assert.ErrorIs(t, q.Put(1), ErrQueueClosed)
+		assert.False(t, q.TryPut(1))
+	})
+
+	t.Run("关闭后可取出剩余元素", func(t *testing.T) {
+		q := NewBlockingQueue[int](2)
+		assert.NoError(t, q.Put(1))
+		assert.NoError(t, q.Put(2))
+		q.Close()
+
+		val, err := q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+
+		val, err = q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, val)
+
+		_, err = q.Take()
+		assert.ErrorIs(t, err, ErrQueueClosed)
+	})
+
+	t.Run("多生产者多消费者", func(t *testing.T) {
+		q := NewBlockingQueue[int](4)
+		const producers, perProducer = 4, 250
+
+		var wg sync.WaitGroup
+		for p := 0; p < producers; p++ {
+			wg.Add(1)
+			go func(base int) {
+				defer wg.Done()
+				for i := 0; i < perProducer; i++ {
+					assert.NoError(t, q.Put(base*perProducer+i))
+				}
+			}(p)
+		}
+
+		var mu sync.Mutex
+		seen := make(map[int]bool)
+		var consumers sync.WaitGroup
+		for c := 0; c < 3; c++ {
+			consumers.Add(1)
+			go func() {
+				defer consumers.Done()
+				for {
+					val, err := q.Take()
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					seen[val] = true
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+		q.Close()
+		consumers.Wait()
+
+		assert.Equal(t, producers*perProducer, len(seen))
+	})
+}
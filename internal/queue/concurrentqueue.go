@@ -0,0 +1,109 @@
+package queue
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// concurrentNode 无锁队列节点
+type concurrentNode[T any] struct {
+	value T
+	next  atomic.Pointer[concurrentNode[T]]
+}
+
+// ConcurrentQueue 无锁并发队列 - 基于Michael-Scott算法实现
+// 支持多生产者多消费者，所有操作均通过CAS完成，不使用互斥锁
+type ConcurrentQueue[T any] struct {
+	head   atomic.Pointer[concurrentNode[T]] // 哨兵节点，head.next为队首
+	tail   atomic.Pointer[concurrentNode[T]]
+	length atomic.Int64
+}
+
+// NewConcurrentQueue 创建新的无锁并发队列
+func NewConcurrentQueue[T any]() *ConcurrentQueue[T] {
+	q := &ConcurrentQueue[T]{}
+	sentinel := &concurrentNode[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Enqueue 入队 - 添加元素到队列尾部
+func (q *ConcurrentQueue[T]) Enqueue(value T) {
+	node := &concurrentNode[T]{value: value}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+
+		if next != nil {
+			// tail落后，帮助推进
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, node) {
+			q.tail.CompareAndSwap(tail, node)
+			q.length.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue 出队 - 从队列头部移除并返回元素
+// 如果队列为空，返回零值和错误
+func (q *ConcurrentQueue[T]) Dequeue() (T, error) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+
+		if next == nil {
+			var zero T
+			return zero, errors.New("queue is empty")
+		}
+
+		if head == tail {
+			// tail落后，帮助推进
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		// 先读取值再移动head，next成为新的哨兵节点
+		value := next.value
+		if q.head.CompareAndSwap(head, next) {
+			q.length.Add(-1)
+			return value, nil
+		}
+	}
+}
+
+// Peek 查看队首元素 - 返回队首元素但不移除
+// 如果队列为空，返回零值和错误
+func (q *ConcurrentQueue[T]) Peek() (T, error) {
+	next := q.head.Load().next.Load()
+	if next == nil {
+		var zero T
+		return zero, errors.New("queue is empty")
+	}
+	return next.value, nil
+}
+
+// Size 返回队列中的元素数量
+// 并发修改时返回的是近似值
+func (q *ConcurrentQueue[T]) Size() int {
+	if n := q.length.Load(); n > 0 {
+		return int(n)
+	}
+	return 0
+}
+
+// IsEmpty 检查队列是否为空
+func (q *ConcurrentQueue[T]) IsEmpty() bool {
+	return q.head.Load().next.Load() == nil
+}
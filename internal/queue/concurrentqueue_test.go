@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentQueue(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		q := NewConcurrentQueue[int]()
+
+		assert.True(t, q.IsEmpty())
+		assert.Equal(t, 0, q.Size())
+
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Enqueue(3)
+
+		assert.False(t, q.IsEmpty())
+		assert.Equal(t, 3, q.Size())
+
+		val, err := q.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+
+		for _, want := range []int{1, 2, 3} {
+			val, err = q.Dequeue()
+			assert.NoError(t, err)
+			assert.Equal(t, want, val)
+		}
+
+		assert.True(t, q.IsEmpty())
+		assert.Equal(t, 0, q.Size())
+	})
+
+	t.Run("错误情况", func(t *testing.T) {
+		q := NewConcurrentQueue[string]()
+
+		_, err := q.Dequeue()
+		assert.Error(t, err)
+
+		_, err = q.Peek()
+		assert.Error(t, err)
+	})
+
+	t.Run("多生产者多消费者", func(t *testing.T) {
+		q := NewConcurrentQueue[int]()
+		const producers, perProducer = 8, 1000
+		total := producers * perProducer
+
+		var wg sync.WaitGroup
+		for p := 0; p < producers; p++ {
+			wg.Add(1)
+			go func(base int) {
+				defer wg.Done()
+				for i := 0; i < perProducer; i++ {
+					q.Enqueue(base*perProducer + i)
+				}
+			}(p)
+		}
+
+		var consumed atomic.Int32
+		results := make([][]int, 4)
+		var consumers sync.WaitGroup
+		for c := range results {
+			consumers.Add(1)
+			go func(c int) {
+				defer consumers.Done()
+				for int(consumed.Load()) < total {
+					val, err := q.Dequeue()
+					if err != nil {
+						runtime.Gosched()
+						continue
+					}
+					consumed.Add(1)
+					results[c] = append(results[c], val)
+				}
+			}(c)
+		}
+
+		wg.Wait()
+		consumers.Wait()
+
+		seen := make(map[int]bool, total)
+		for _, res := range results {
+			// 同一生产者的元素在每个消费者中保持FIFO顺序
+			last := make(map[int]int)
+			for _, v := range res {
+				assert.False(t, seen[v])
+				seen[v] = true
+				producer := v / perProducer
+				if prev, ok := last[producer]; ok {
+					assert.Less(t, prev, v)
+				}
+				last[producer] = v
+			}
+		}
+		assert.Equal(t, total, len(seen))
+		assert.True(t, q.IsEmpty())
+	})
+}
+
+// mutexQueue 使用互斥锁保护的Queue，用于基准对比
+type mutexQueue[T any] struct {
+	mu sync.Mutex
+	q  *Queue[T]
+}
+
+func (m *mutexQueue[T]) Enqueue(value T) {
+	m.mu.Lock()
+	m.q.Enqueue(value)
+	m.mu.Unlock()
+}
+
+func (m *mutexQueue[T]) Dequeue() (T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.q.Dequeue()
+}
+
+func BenchmarkConcurrentQueue(b *testing.B) {
+	q := NewConcurrentQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				q.Enqueue(i)
+			} else {
+				_, _ = q.Dequeue()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMutexQueue(b *testing.B) {
+	q := &mutexQueue[int]{q: NewQueue[int]()}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				q.Enqueue(i)
+			} else {
+				_, _ = q.Dequeue()
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Clock 时钟接口，可在测试中注入假时钟以避免真实等待
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+	// After 返回一个在d之后收到当前时间的通道
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock 基于系统时间的时钟
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock 返回基于系统时间的时钟
+func SystemClock() Clock {
+	return realClock{}
+}
+
+// delayItem 延迟队列中的元素
+type delayItem[T any] struct {
+	value T
+	at    time.Time // 元素可被取出的时间
+}
+
+// DelayQueue 延迟队列 - 基于最小堆实现，按到期时间排序
+// 元素只有在到期之后才能被取出，可安全地在多个协程间共享
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	items   []delayItem[T]
+	clock   Clock
+	closed  bool
+	changed notifier // 队首元素变化或队列关闭时广播
+}
+
+// NewDelayQueue 创建新的延迟队列
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](SystemClock())
+}
+
+// NewDelayQueueWithClock 创建使用指定时钟的延迟队列
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	return &DelayQueue[T]{
+		items: make([]delayItem[T], 0),
+		clock: clock,
+	}
+}
+
+// Put 入队 - 元素在at时刻之后可被取出
+func (dq *DelayQueue[T]) Put(value T, at time.Time) error {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if dq.closed {
+		return ErrQueueClosed
+	}
+
+	dq.items = append(dq.items, delayItem[T]{value: value, at: at})
+	dq.siftUp(len(dq.items) - 1)
+
+	// 新元素成为队首时需要唤醒等待者重新计算等待时间
+	if dq.items[0].at.Equal(at) {
+		dq.changed.broadcast()
+	}
+	return nil
+}
+
+// PutDelay 入队 - 元素在delay之后可被取出
+func (dq *DelayQueue[T]) PutDelay(value T, delay time.Duration) error {
+	return dq.Put(value, dq.clock.Now().Add(delay))
+}
+
+// Take 出队 - 阻塞直到队首元素到期、ctx结束或队列关闭
+// 队列关闭后只能取出已到期的元素，否则返回ErrQueueClosed
+func (dq *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	dq.mu.Lock()
+	for {
+		var timer <-chan time.Time
+		if len(dq.items) > 0 {
+			delay := dq.items[0].at.Sub(dq.clock.Now())
+			if delay <= 0 {
+				value := dq.pop()
+				dq.mu.Unlock()
+				return value, nil
+			}
+			timer = dq.clock.After(delay)
+		}
+		if dq.closed {
+			dq.mu.Unlock()
+			var zero T
+			return zero, ErrQueueClosed
+		}
+
+		wait := dq.changed.wait()
+		dq.mu.Unlock()
+		select {
+		case <-wait:
+		case <-timer:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		dq.mu.Lock()
+	}
+}
+
+// Poll 非阻塞出队，没有到期元素时返回false
+func (dq *DelayQueue[T]) Poll() (T, bool) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if len(dq.items) == 0 || dq.items[0].at.After(dq.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+	return dq.pop(), true
+}
+
+// Peek 查看最早到期的元素及其到期时间，不要求元素已到期
+func (dq *DelayQueue[T]) Peek() (T, time.Time, error) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if len(dq.items) == 0 {
+		var zero T
+		return zero, time.Time{}, errors.New("delay queue is empty")
+	}
+	return dq.items[0].value, dq.items[0].at, nil
+}
+
+// Close 关闭队列并唤醒所有等待的协程
+func (dq *DelayQueue[T]) Close() {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	if dq.closed {
+		return
+	}
+	dq.closed = true
+	dq.changed.broadcast()
+}
+
+// Size 返回队列中的元素数量（包括未到期的元素）
+func (dq *DelayQueue[T]) Size() int {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return len(dq.items)
+}
+
+// IsEmpty 检查队列是否为空
+func (dq *DelayQueue[T]) IsEmpty() bool {
+	return dq.Size() == 0
+}
+
+// Clear 清空队列
+func (dq *DelayQueue[T]) Clear() {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+
+	clear(dq.items)
+	dq.items = dq.items[:0]
+	dq.changed.broadcast()
+}
+
+// pop 移除并返回队首元素（调用方需持有锁）
+func (dq *DelayQueue[T]) pop() T {
+	value := dq.items[0].value
+
+	lastIndex := len(dq.items) - 1
+	dq.items[0] = dq.items[lastIndex]
+	dq.items[lastIndex] = delayItem[T]{}
+	dq.items = dq.items[:lastIndex]
+	if len(dq.items) > 0 {
+		dq.siftDown(0)
+	}
+	return value
+}
+
+// siftUp 向上堆化
+func (dq *DelayQueue[T]) siftUp(index int) {
+	siftUp(index, dq.less, dq.swap)
+}
+
+// siftDown 向下堆化
+func (dq *DelayQueue[T]) siftDown(index int) {
+	siftDown(index, len(dq.items), dq.less, dq.swap)
+}
+
+// less 到期时间越早越优先
+func (dq *DelayQueue[T]) less(i, j int) bool {
+	return dq.items[i].at.Before(dq.items[j].at)
+}
+
+// swap 交换两个元素
+func (dq *DelayQueue[T]) swap(i, j int) {
+	dq.items[i], dq.items[j] = dq.items[j], dq.items[i]
+}
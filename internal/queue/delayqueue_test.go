@@ -0,0 +1,208 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.at.After(c.now) {
+			w.ch <- c.now
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+}
+
+func (c *fakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+func TestDelayQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("按到期时间出队", func(t *testing.T) {
+		clock := newFakeClock()
+		dq := NewDelayQueueWithClock[string](clock)
+
+		assert.NoError(t, dq.PutDelay("c", 3*time.Second))
+		assert.NoError(t, dq.PutDelay("a", time.Second))
+		assert.NoError(t, dq.PutDelay("b", 2*time.Second))
+		assert.Equal(t, 3, dq.Size())
+
+		val, at, err := dq.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, "a", val)
+		assert.Equal(t, clock.Now().Add(time.Second), at)
+
+		// 未到期
+		_, ok := dq.Poll()
+		assert.False(t, ok)
+
+		clock.Advance(2 * time.Second)
+		val, ok = dq.Poll()
+		assert.True(t, ok)
+		assert.Equal(t, "a", val)
+		val, ok = dq.Poll()
+		assert.True(t, ok)
+		assert.Equal(t, "b", val)
+		_, ok = dq.Poll()
+		assert.False(t, ok)
+
+		clock.Advance(time.Second)
+		val, err = dq.Take(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "c", val)
+		assert.True(t, dq.IsEmpty())
+	})
+
+	t.Run("Take阻塞直到到期", func(t *testing.T) {
+		clock := newFakeClock()
+		dq := NewDelayQueueWithClock[int](clock)
+		assert.NoError(t, dq.PutDelay(1, time.Minute))
+
+		result := make(chan int)
+		go func() {
+			val, err := dq.Take(ctx)
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		clock.Advance(30 * time.Second)
+		select {
+		case <-result:
+			t.Fatal("Take should block until the delay expires")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		clock.Advance(30 * time.Second)
+		assert.Equal(t, 1, <-result)
+	})
+
+	t.Run("更早到期的元素唤醒等待者", func(t *testing.T) {
+		clock := newFakeClock()
+		dq := NewDelayQueueWithClock[int](clock)
+		assert.NoError(t, dq.PutDelay(1, time.Hour))
+
+		result := make(chan int)
+		go func() {
+			val, err := dq.Take(ctx)
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		assert.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+		assert.NoError(t, dq.PutDelay(2, time.Second))
+		assert.Eventually(t, func() bool { return clock.Waiters() == 2 }, time.Second, time.Millisecond)
+
+		clock.Advance(time.Second)
+		assert.Equal(t, 2, <-result)
+		assert.Equal(t, 1, dq.Size())
+	})
+
+	t.Run("空队列等待新元素", func(t *testing.T) {
+		clock := newFakeClock()
+		dq := NewDelayQueueWithClock[int](clock)
+
+		result := make(chan int)
+		go func() {
+			val, err := dq.Take(ctx)
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, dq.Put(5, clock.Now()))
+		assert.Equal(t, 5, <-result)
+	})
+
+	t.Run("取消和关闭", func(t *testing.T) {
+		clock := newFakeClock()
+		dq := NewDelayQueueWithClock[int](clock)
+		assert.NoError(t, dq.PutDelay(1, time.Hour))
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := dq.Take(timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		done := make(chan error)
+		go func() {
+			_, err := dq.Take(ctx)
+			done <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		dq.Close()
+		assert.ErrorIs(t, <-done, ErrQueueClosed)
+		assert.ErrorIs(t, dq.PutDelay(2, 0), ErrQueueClosed)
+
+		// 关闭后仍可取出已到期的元素
+		clock.Advance(time.Hour)
+		val, err := dq.Take(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+	})
+
+	t.Run("真实时钟", func(t *testing.T) {
+		dq := NewDelayQueue[int]()
+		start := time.Now()
+		assert.NoError(t, dq.PutDelay(1, 20*time.Millisecond))
+
+		val, err := dq.Take(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+		_, _, err = dq.Peek()
+		assert.Error(t, err)
+	})
+}
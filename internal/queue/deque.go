@@ -0,0 +1,221 @@
+package queue
+
+import (
+	"errors"
+	"iter"
+)
+
+// dequeChunkSize 每个分块存放的元素数量
+const dequeChunkSize = 64
+
+// Deque 双端队列
+// 基于分块环形缓冲区实现：元素存放在固定大小的分块中，分块指针按需在两端扩展，
+// 两端的入队出队均为均摊O(1)，按下标访问为O(1)
+type Deque[T any] struct {
+	chunks []*[dequeChunkSize]T // 分块表，未使用的位置为nil
+	start  int                  // 队首元素的位置：chunks[start/dequeChunkSize][start%dequeChunkSize]
+	length int
+}
+
+// NewDeque 创建新的双端队列
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// PushFront 添加元素到队首
+func (d *Deque[T]) PushFront(value T) {
+	if d.start == 0 {
+		d.growMap()
+	}
+	d.start--
+	*d.slot(d.start) = value
+	d.length++
+}
+
+// PushBack 添加元素到队尾
+func (d *Deque[T]) PushBack(value T) {
+	if d.start+d.length == len(d.chunks)*dequeChunkSize {
+		d.growMap()
+	}
+	*d.slot(d.start + d.length) = value
+	d.length++
+}
+
+// PopFront 移除并返回队首元素
+func (d *Deque[T]) PopFront() (T, error) {
+	if d.length == 0 {
+		var zero T
+		return zero, errors.New("deque is empty")
+	}
+
+	pos := d.start
+	value := d.release(pos)
+	d.start++
+	d.length--
+
+	// 分块中的元素已全部移除
+	if d.start%dequeChunkSize == 0 || d.length == 0 {
+		d.chunks[pos/dequeChunkSize] = nil
+	}
+	d.recenterIfEmpty()
+	return value, nil
+}
+
+// PopBack 移除并返回队尾元素
+func (d *Deque[T]) PopBack() (T, error) {
+	if d.length == 0 {
+		var zero T
+		return zero, errors.New("deque is empty")
+	}
+
+	pos := d.start + d.length - 1
+	value := d.release(pos)
+	d.length--
+
+	// 分块中的元素已全部移除
+	if pos%dequeChunkSize == 0 || d.length == 0 {
+		d.chunks[pos/dequeChunkSize] = nil
+	}
+	d.recenterIfEmpty()
+	return value, nil
+}
+
+// PeekFront 查看队首元素
+func (d *Deque[T]) PeekFront() (T, error) {
+	if d.length == 0 {
+		var zero T
+		return zero, errors.New("deque is empty")
+	}
+	return *d.slot(d.start), nil
+}
+
+// PeekBack 查看队尾元素
+func (d *Deque[T]) PeekBack() (T, error) {
+	if d.length == 0 {
+		var zero T
+		return zero, errors.New("deque is empty")
+	}
+	return *d.slot(d.start + d.length - 1), nil
+}
+
+// Get 获取指定位置的元素，0为队首
+func (d *Deque[T]) Get(index int) (T, error) {
+	if index < 0 || index >= d.length {
+		var zero T
+		return zero, errors.New("index out of range")
+	}
+	return *d.slot(d.start + index), nil
+}
+
+// Set 设置指定位置的元素值，0为队首
+func (d *Deque[T]) Set(index int, value T) error {
+	if index < 0 || index >= d.length {
+		return errors.New("index out of range")
+	}
+	*d.slot(d.start + index) = value
+	return nil
+}
+
+// Size 返回元素数量
+func (d *Deque[T]) Size() int {
+	return d.length
+}
+
+// IsEmpty 检查是否为空
+func (d *Deque[T]) IsEmpty() bool {
+	return d.length == 0
+}
+
+// Clear 清空双端队列并释放所有分块
+func (d *Deque[T]) Clear() {
+	d.chunks = nil
+	d.start = 0
+	d.length = 0
+}
+
+// ToSlice 转换为切片（从队首到队尾）
+func (d *Deque[T]) ToSlice() []T {
+	result := make([]T, 0, d.length)
+	for _, v := range d.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// All 返回从队首到队尾的迭代器
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.length; i++ {
+			if !yield(i, *d.slot(d.start + i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回从队尾到队首的迭代器
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.length - 1; i >= 0; i-- {
+			if !yield(i, *d.slot(d.start + i)) {
+				return
+			}
+		}
+	}
+}
+
+// CollectDeque 从迭代器创建双端队列，元素依次从队尾加入
+func CollectDeque[T any](seq iter.Seq[T]) *Deque[T] {
+	d := NewDeque[T]()
+	for v := range seq {
+		d.PushBack(v)
+	}
+	return d
+}
+
+// slot 返回指定位置的元素指针，分块不存在时创建
+func (d *Deque[T]) slot(pos int) *T {
+	chunk := d.chunks[pos/dequeChunkSize]
+	if chunk == nil {
+		chunk = new([dequeChunkSize]T)
+		d.chunks[pos/dequeChunkSize] = chunk
+	}
+	return &chunk[pos%dequeChunkSize]
+}
+
+// release 取出指定位置的元素并清空该位置，便于GC回收
+func (d *Deque[T]) release(pos int) T {
+	p := d.slot(pos)
+	value := *p
+	var zero T
+	*p = zero
+	return value
+}
+
+// recenterIfEmpty 队列为空时将起始位置移到分块表中间，两端都留出空间
+func (d *Deque[T]) recenterIfEmpty() {
+	if d.length == 0 {
+		d.start = len(d.chunks) / 2 * dequeChunkSize
+	}
+}
+
+// growMap 扩展分块表，使已使用的分块位于中间（内部方法）
+func (d *Deque[T]) growMap() {
+	lo, used := 0, 0
+	if d.length > 0 {
+		lo = d.start / dequeChunkSize
+		used = (d.start+d.length-1)/dequeChunkSize - lo + 1
+	}
+
+	// 空闲位置足够时只需移动到中间，否则容量翻倍
+	newLen := len(d.chunks)
+	if used+2 > newLen/2 {
+		newLen = max(newLen*2, 4)
+	}
+
+	chunks := make([]*[dequeChunkSize]T, newLen)
+	offset := (newLen - used) / 2
+	copy(chunks[offset:], d.chunks[lo:lo+used])
+	d.chunks = chunks
+	d.start = offset*dequeChunkSize + d.start%dequeChunkSize
+}
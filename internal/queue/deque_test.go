@@ -0,0 +1,201 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		d := NewDeque[int]()
+
+		assert.True(t, d.IsEmpty())
+		assert.Equal(t, 0, d.Size())
+
+		d.PushBack(2)
+		d.PushBack(3)
+		d.PushFront(1)
+		d.PushFront(0)
+
+		assert.Equal(t, 4, d.Size())
+		assert.Equal(t, []int{0, 1, 2, 3}, d.ToSlice())
+
+		val, err := d.PeekFront()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, val)
+
+		val, err = d.PeekBack()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, val)
+
+		val, err = d.PopFront()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, val)
+
+		val, err = d.PopBack()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, val)
+
+		assert.Equal(t, []int{1, 2}, d.ToSlice())
+
+		d.Clear()
+		assert.True(t, d.IsEmpty())
+	})
+
+	t.Run("错误情况", func(t *testing.T) {
+		d := NewDeque[int]()
+
+		_, err := d.PopFront()
+		assert.Error(t, err)
+		_, err = d.PopBack()
+		assert.Error(t, err)
+		_, err = d.PeekFront()
+		assert.Error(t, err)
+		_, err = d.PeekBack()
+		assert.Error(t, err)
+		_, err = d.Get(0)
+		assert.Error(t, err)
+		assert.Error(t, d.Set(0, 1))
+
+		d.PushBack(1)
+		_, err = d.Get(-1)
+		assert.Error(t, err)
+		_, err = d.Get(1)
+		assert.Error(t, err)
+	})
+
+	t.Run("按下标访问", func(t *testing.T) {
+		d := NewDeque[int]()
+		for i := 0; i < 200; i++ {
+			d.PushBack(i)
+		}
+		for i := 1; i <= 100; i++ {
+			d.PushFront(-i)
+		}
+
+		assert.Equal(t, 300, d.Size())
+		for i := 0; i < 300; i++ {
+			val, err := d.Get(i)
+			assert.NoError(t, err)
+			assert.Equal(t, i-100, val)
+		}
+
+		assert.NoError(t, d.Set(150, 999))
+		val, err := d.Get(150)
+		assert.NoError(t, err)
+		assert.Equal(t, 999, val)
+	})
+
+	t.Run("双向迭代", func(t *testing.T) {
+		d := NewDeque[string]()
+		d.PushBack("b")
+		d.PushBack("c")
+		d.PushFront("a")
+
+		var forward []string
+		for i, v := range d.All() {
+			assert.Equal(t, len(forward), i)
+			forward = append(forward, v)
+		}
+		assert.Equal(t, []string{"a", "b", "c"}, forward)
+
+		var backward []string
+		for _, v := range d.Backward() {
+			backward = append(backward, v)
+		}
+		assert.Equal(t, []string{"c", "b", "a"}, backward)
+
+		// 提前终止
+		count := 0
+		for range d.All() {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("作为栈和队列使用", func(t *testing.T) {
+		d := NewDeque[int]()
+		const n = 1000
+
+		// 栈：后进先出
+		for i := 0; i < n; i++ {
+			d.PushBack(i)
+		}
+		for i := n - 1; i >= 0; i-- {
+			val, err := d.PopBack()
+			assert.NoError(t, err)
+			assert.Equal(t, i, val)
+		}
+		assert.True(t, d.IsEmpty())
+
+		// 队列：从队首入、队尾出
+		for i := 0; i < n; i++ {
+			d.PushFront(i)
+		}
+		for i := 0; i < n; i++ {
+			val, err := d.PopBack()
+			assert.NoError(t, err)
+			assert.Equal(t, i, val)
+		}
+		assert.True(t, d.IsEmpty())
+	})
+
+	t.Run("滑动窗口不会无限扩展分块表", func(t *testing.T) {
+		d := NewDeque[int]()
+		for i := 0; i < 10; i++ {
+			d.PushBack(i)
+		}
+		for i := 10; i < 100000; i++ {
+			d.PushBack(i)
+			val, err := d.PopFront()
+			assert.NoError(t, err)
+			assert.Equal(t, i-10, val)
+		}
+		assert.Equal(t, 10, d.Size())
+		assert.LessOrEqual(t, len(d.chunks), 8)
+
+		allocated := 0
+		for _, chunk := range d.chunks {
+			if chunk != nil {
+				allocated++
+			}
+		}
+		assert.LessOrEqual(t, allocated, 2)
+	})
+
+	t.Run("与切片模型对比", func(t *testing.T) {
+		d := NewDeque[int]()
+		var model []int
+
+		for i := 0; i < 5000; i++ {
+			switch i * 7 % 5 {
+			case 0, 1:
+				d.PushBack(i)
+				model = append(model, i)
+			case 2:
+				d.PushFront(i)
+				model = append([]int{i}, model...)
+			case 3:
+				val, err := d.PopFront()
+				if len(model) == 0 {
+					assert.Error(t, err)
+					continue
+				}
+				assert.Equal(t, model[0], val)
+				model = model[1:]
+			case 4:
+				val, err := d.PopBack()
+				if len(model) == 0 {
+					assert.Error(t, err)
+					continue
+				}
+				assert.Equal(t, model[len(model)-1], val)
+				model = model[:len(model)-1]
+			}
+		}
+		assert.Equal(t, len(model), d.Size())
+		assert.Equal(t, model, d.ToSlice())
+	})
+}
@@ -0,0 +1,44 @@
+package queue
+
+// siftUp 向上堆化（插入时使用）
+// less(i, j) 表示下标i的元素优先于下标j的元素，swap交换两个下标的元素
+func siftUp(index int, less func(i, j int) bool, swap func(i, j int)) {
+	for index > 0 {
+		parent := (index - 1) / 2
+
+		// 如果当前元素优先于父节点，交换
+		if !less(index, parent) {
+			break
+		}
+		swap(index, parent)
+		index = parent
+	}
+}
+
+// siftDown 向下堆化（删除时使用）
+// 返回元素是否发生了移动
+func siftDown(index, length int, less func(i, j int) bool, swap func(i, j int)) bool {
+	start := index
+	for {
+		left := 2*index + 1
+		right := 2*index + 2
+		smallest := index
+
+		// 找到子节点中优先的那个
+		if left < length && less(left, smallest) {
+			smallest = left
+		}
+		if right < length && less(right, smallest) {
+			smallest = right
+		}
+
+		// 如果当前节点已经是最优先的，停止
+		if smallest == index {
+			break
+		}
+
+		swap(index, smallest)
+		index = smallest
+	}
+	return index > start
+}
@@ -0,0 +1,163 @@
+package queue
+
+import "errors"
+
+// Handle 索引优先级队列中元素的句柄，用于修改优先级或删除元素
+type Handle[T any] struct {
+	value    T
+	priority int
+	index    int // 元素在堆中的下标，-1表示已不在队列中
+}
+
+// Value 返回句柄对应的元素值
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// Priority 返回句柄对应的优先级
+func (h *Handle[T]) Priority() int {
+	return h.priority
+}
+
+// IndexedPriorityQueue 索引优先级队列 - 基于最小堆实现
+// Enqueue返回元素的句柄，可通过句柄在O(log n)内修改优先级或删除元素
+type IndexedPriorityQueue[T any] struct {
+	items []*Handle[T]
+}
+
+// NewIndexedPriorityQueue 创建新的索引优先级队列
+func NewIndexedPriorityQueue[T any]() *IndexedPriorityQueue[T] {
+	return &IndexedPriorityQueue[T]{
+		items: make([]*Handle[T], 0),
+	}
+}
+
+// Enqueue 入队 - 添加元素和优先级，返回元素的句柄
+func (pq *IndexedPriorityQueue[T]) Enqueue(value T, priority int) *Handle[T] {
+	h := &Handle[T]{
+		value:    value,
+		priority: priority,
+		index:    len(pq.items),
+	}
+	pq.items = append(pq.items, h)
+	pq.siftUp(h.index)
+	return h
+}
+
+// Dequeue 出队 - 移除并返回优先级最高的元素
+func (pq *IndexedPriorityQueue[T]) Dequeue() (T, int, error) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, 0, errors.New("priority queue is empty")
+	}
+
+	h := pq.removeAt(0)
+	return h.value, h.priority, nil
+}
+
+// Peek 查看优先级最高的元素
+func (pq *IndexedPriorityQueue[T]) Peek() (T, int, error) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, 0, errors.New("priority queue is empty")
+	}
+
+	return pq.items[0].value, pq.items[0].priority, nil
+}
+
+// Update 修改句柄对应元素的优先级
+func (pq *IndexedPriorityQueue[T]) Update(h *Handle[T], priority int) error {
+	if !pq.Contains(h) {
+		return errors.New("handle is not in the queue")
+	}
+
+	h.priority = priority
+	if !pq.siftDown(h.index) {
+		pq.siftUp(h.index)
+	}
+	return nil
+}
+
+// Remove 删除句柄对应的元素
+func (pq *IndexedPriorityQueue[T]) Remove(h *Handle[T]) error {
+	if !pq.Contains(h) {
+		return errors.New("handle is not in the queue")
+	}
+
+	pq.removeAt(h.index)
+	return nil
+}
+
+// Contains 检查句柄对应的元素是否仍在队列中
+func (pq *IndexedPriorityQueue[T]) Contains(h *Handle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.items) && pq.items[h.index] == h
+}
+
+// Size 返回队列中的元素数量
+func (pq *IndexedPriorityQueue[T]) Size() int {
+	return len(pq.items)
+}
+
+// IsEmpty 检查队列是否为空
+func (pq *IndexedPriorityQueue[T]) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+// Clear 清空队列，已有句柄全部失效
+func (pq *IndexedPriorityQueue[T]) Clear() {
+	for i, h := range pq.items {
+		h.index = -1
+		pq.items[i] = nil
+	}
+	pq.items = pq.items[:0]
+}
+
+// ToSlice 转换为切片（堆中的顺序）
+func (pq *IndexedPriorityQueue[T]) ToSlice() []PriorityItem[T] {
+	result := make([]PriorityItem[T], len(pq.items))
+	for i, h := range pq.items {
+		result[i] = PriorityItem[T]{Value: h.value, Priority: h.priority}
+	}
+	return result
+}
+
+// removeAt 删除指定下标的元素并返回其句柄
+func (pq *IndexedPriorityQueue[T]) removeAt(index int) *Handle[T] {
+	lastIndex := len(pq.items) - 1
+	if index != lastIndex {
+		pq.swap(index, lastIndex)
+	}
+
+	h := pq.items[lastIndex]
+	pq.items[lastIndex] = nil
+	pq.items = pq.items[:lastIndex]
+	h.index = -1
+
+	// 被移到index处的元素可能需要向上或向下调整
+	if index < len(pq.items) && !pq.siftDown(index) {
+		pq.siftUp(index)
+	}
+	return h
+}
+
+// siftUp 向上堆化
+func (pq *IndexedPriorityQueue[T]) siftUp(index int) {
+	siftUp(index, pq.less, pq.swap)
+}
+
+// siftDown 向下堆化，返回元素是否发生了移动
+func (pq *IndexedPriorityQueue[T]) siftDown(index int) bool {
+	return siftDown(index, len(pq.items), pq.less, pq.swap)
+}
+
+// less 比较两个元素的优先级，数值越小优先级越高
+func (pq *IndexedPriorityQueue[T]) less(i, j int) bool {
+	return pq.items[i].priority < pq.items[j].priority
+}
+
+// swap 交换两个元素并更新句柄中的下标
+func (pq *IndexedPriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		pq := NewIndexedPriorityQueue[string]()
+		assert.True(t, pq.IsEmpty())
+
+		pq.Enqueue("c", 3)
+		h := pq.Enqueue("a", 1)
+		pq.Enqueue("b", 2)
+
+		assert.Equal(t, 3, pq.Size())
+		assert.True(t, pq.Contains(h))
+		assert.Equal(t, "a", h.Value())
+		assert.Equal(t, 1, h.Priority())
+
+		val, priority, err := pq.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, "a", val)
+		assert.Equal(t, 1, priority)
+
+		for _, want := range []string{"a", "b", "c"} {
+			val, _, err = pq.Dequeue()
+			assert.NoError(t, err)
+			assert.Equal(t, want, val)
+		}
+		assert.False(t, pq.Contains(h))
+
+		_, _, err = pq.Dequeue()
+		assert.Error(t, err)
+		_, _, err = pq.Peek()
+		assert.Error(t, err)
+	})
+
+	t.Run("修改优先级", func(t *testing.T) {
+		pq := NewIndexedPriorityQueue[string]()
+		a := pq.Enqueue("a", 1)
+		b := pq.Enqueue("b", 2)
+		c := pq.Enqueue("c", 3)
+
+		// 降低优先级
+		assert.NoError(t, pq.Update(a, 10))
+		val, _, _ := pq.Peek()
+		assert.Equal(t, "b", val)
+
+		// 提高优先级
+		assert.NoError(t, pq.Update(c, 0))
+		val, priority, _ := pq.Peek()
+		assert.Equal(t, "c", val)
+		assert.Equal(t, 0, priority)
+		assert.Equal(t, 0, c.Priority())
+
+		var order []string
+		for !pq.IsEmpty() {
+			v, _, _ := pq.Dequeue()
+			order = append(order, v)
+		}
+		assert.Equal(t, []string{"c", "b", "a"}, order)
+
+		// 已出队的句柄不能再修改
+		assert.Error(t, pq.Update(b, 1))
+	})
+
+	t.Run("删除元素", func(t *testing.T) {
+		pq := NewIndexedPriorityQueue[int]()
+		handles := make([]*Handle[int], 0, 10)
+		for i := 0; i < 10; i++ {
+			handles = append(handles, pq.Enqueue(i, i))
+		}
+
+		assert.NoError(t, pq.Remove(handles[0]))
+		assert.NoError(t, pq.Remove(handles[5]))
+		assert.NoError(t, pq.Remove(handles[9]))
+		assert.Error(t, pq.Remove(handles[5]))
+		assert.Error(t, pq.Remove(nil))
+		assert.Equal(t, 7, pq.Size())
+
+		var order []int
+		for !pq.IsEmpty() {
+			v, _, _ := pq.Dequeue()
+			order = append(order, v)
+		}
+		assert.Equal(t, []int{1, 2, 3, 4, 6, 7, 8}, order)
+	})
+
+	t.Run("其他队列的句柄", func(t *testing.T) {
+		pq1 := NewIndexedPriorityQueue[int]()
+		pq2 := NewIndexedPriorityQueue[int]()
+		h := pq1.Enqueue(1, 1)
+		pq2.Enqueue(2, 2)
+
+		assert.False(t, pq2.Contains(h))
+		assert.Error(t, pq2.Update(h, 0))
+		assert.Error(t, pq2.Remove(h))
+	})
+
+	t.Run("清空", func(t *testing.T) {
+		pq := NewIndexedPriorityQueue[int]()
+		h := pq.Enqueue(1, 1)
+		pq.Enqueue(2, 2)
+
+		pq.Clear()
+		assert.True(t, pq.IsEmpty())
+		assert.False(t, pq.Contains(h))
+		assert.Empty(t, pq.ToSlice())
+	})
+
+	t.Run("随机操作", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		pq := NewIndexedPriorityQueue[int]()
+		live := make(map[*Handle[int]]bool)
+
+		for i := 0; i < 2000; i++ {
+			switch r.Intn(4) {
+			case 0, 1:
+				live[pq.Enqueue(i, r.Intn(100))] = true
+			case 2:
+				for h := range live {
+					assert.NoError(t, pq.Update(h, r.Intn(100)))
+					break
+				}
+			case 3:
+				for h := range live {
+					assert.NoError(t, pq.Remove(h))
+					delete(live, h)
+					break
+				}
+			}
+		}
+
+		want := make([]int, 0, len(live))
+		for h := range live {
+			want = append(want, h.Priority())
+		}
+		sort.Ints(want)
+
+		got := make([]int, 0, len(live))
+		for !pq.IsEmpty() {
+			_, priority, err := pq.Dequeue()
+			assert.NoError(t, err)
+			got = append(got, priority)
+		}
+		assert.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,163 @@
+package queue
+
+import "errors"
+
+// FuncHandle 自定义比较索引优先级队列中元素的句柄，用于修改或删除元素
+type FuncHandle[T any] struct {
+	value T
+	index int // 元素在堆中的下标，-1表示已不在队列中
+}
+
+// Value 返回句柄对应的元素值
+func (h *FuncHandle[T]) Value() T {
+	return h.value
+}
+
+// IndexedPriorityQueueFunc 使用自定义比较函数的索引优先级队列 - 基于二叉堆实现
+// Enqueue返回元素的句柄，可通过句柄在O(log n)内修改或删除元素
+// 适用于time.Time等不能用int表示优先级的场景
+type IndexedPriorityQueueFunc[T any] struct {
+	items []*FuncHandle[T]
+	cmp   func(a, b T) int
+}
+
+// NewIndexedPriorityQueueFunc 创建使用比较函数cmp的索引优先级队列
+// cmp(a, b) 返回负数表示a优先于b；cmp为nil时panic
+func NewIndexedPriorityQueueFunc[T any](cmp func(a, b T) int) *IndexedPriorityQueueFunc[T] {
+	if cmp == nil {
+		panic("queue: 比较函数不能为nil")
+	}
+	return &IndexedPriorityQueueFunc[T]{
+		items: make([]*FuncHandle[T], 0),
+		cmp:   cmp,
+	}
+}
+
+// Enqueue 入队 - 添加元素，返回元素的句柄
+func (pq *IndexedPriorityQueueFunc[T]) Enqueue(value T) *FuncHandle[T] {
+	h := &FuncHandle[T]{
+		value: value,
+		index: len(pq.items),
+	}
+	pq.items = append(pq.items, h)
+	pq.siftUp(h.index)
+	return h
+}
+
+// Dequeue 出队 - 移除并返回优先级最高的元素
+func (pq *IndexedPriorityQueueFunc[T]) Dequeue() (T, error) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return pq.removeAt(0).value, nil
+}
+
+// Peek 查看优先级最高的元素
+func (pq *IndexedPriorityQueueFunc[T]) Peek() (T, error) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	return pq.items[0].value, nil
+}
+
+// Update 将句柄对应的元素替换为value并调整其位置
+// 元素为指针且优先级字段已在原处修改时，传入同一个指针即可
+func (pq *IndexedPriorityQueueFunc[T]) Update(h *FuncHandle[T], value T) error {
+	if !pq.Contains(h) {
+		return errors.New("handle is not in the queue")
+	}
+
+	h.value = value
+	if !pq.siftDown(h.index) {
+		pq.siftUp(h.index)
+	}
+	return nil
+}
+
+// Remove 删除句柄对应的元素
+func (pq *IndexedPriorityQueueFunc[T]) Remove(h *FuncHandle[T]) error {
+	if !pq.Contains(h) {
+		return errors.New("handle is not in the queue")
+	}
+
+	pq.removeAt(h.index)
+	return nil
+}
+
+// Contains 检查句柄对应的元素是否仍在队列中
+func (pq *IndexedPriorityQueueFunc[T]) Contains(h *FuncHandle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.items) && pq.items[h.index] == h
+}
+
+// Size 返回队列中的元素数量
+func (pq *IndexedPriorityQueueFunc[T]) Size() int {
+	return len(pq.items)
+}
+
+// IsEmpty 检查队列是否为空
+func (pq *IndexedPriorityQueueFunc[T]) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+// Clear 清空队列，已有句柄全部失效
+func (pq *IndexedPriorityQueueFunc[T]) Clear() {
+	for i, h := range pq.items {
+		h.index = -1
+		pq.items[i] = nil
+	}
+	pq.items = pq.items[:0]
+}
+
+// ToSlice 转换为切片（堆中的顺序）
+func (pq *IndexedPriorityQueueFunc[T]) ToSlice() []T {
+	result := make([]T, len(pq.items))
+	for i, h := range pq.items {
+		result[i] = h.value
+	}
+	return result
+}
+
+// removeAt 删除指定下标的元素并返回其句柄
+func (pq *IndexedPriorityQueueFunc[T]) removeAt(index int) *FuncHandle[T] {
+	lastIndex := len(pq.items) - 1
+	if index != lastIndex {
+		pq.swap(index, lastIndex)
+	}
+
+	h := pq.items[lastIndex]
+	pq.items[lastIndex] = nil
+	pq.items = pq.items[:lastIndex]
+	h.index = -1
+
+	// 被移到index处的元素可能需要向上或向下调整
+	if index < len(pq.items) && !pq.siftDown(index) {
+		pq.siftUp(index)
+	}
+	return h
+}
+
+// siftUp 向上堆化
+func (pq *IndexedPriorityQueueFunc[T]) siftUp(index int) {
+	siftUp(index, pq.less, pq.swap)
+}
+
+// siftDown 向下堆化，返回元素是否发生了移动
+func (pq *IndexedPriorityQueueFunc[T]) siftDown(index int) bool {
+	return siftDown(index, len(pq.items), pq.less, pq.swap)
+}
+
+// less 使用比较函数判断优先级
+func (pq *IndexedPriorityQueueFunc[T]) less(i, j int) bool {
+	return pq.cmp(pq.items[i].value, pq.items[j].value) < 0
+}
+
+// swap 交换两个元素并更新句柄中的下标
+func (pq *IndexedPriorityQueueFunc[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedPriorityQueueFunc(t *testing.T) {
+	type task struct {
+		name string
+		at   time.Time
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	byTime := func(a, b *task) int {
+		return a.at.Compare(b.at)
+	}
+
+	t.Run("基本操作", func(t *testing.T) {
+		pq := NewIndexedPriorityQueueFunc(byTime)
+		assert.True(t, pq.IsEmpty())
+
+		pq.Enqueue(&task{"c", base.Add(3 * time.Hour)})
+		h := pq.Enqueue(&task{"a", base.Add(time.Hour)})
+		pq.Enqueue(&task{"b", base.Add(2 * time.Hour)})
+
+		assert.Equal(t, 3, pq.Size())
+		assert.True(t, pq.Contains(h))
+		assert.Equal(t, "a", h.Value().name)
+
+		val, err := pq.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, "a", val.name)
+
+		for _, want := range []string{"a", "b", "c"} {
+			val, err = pq.Dequeue()
+			assert.NoError(t, err)
+			assert.Equal(t, want, val.name)
+		}
+		assert.False(t, pq.Contains(h))
+
+		_, err = pq.Dequeue()
+		assert.Error(t, err)
+		_, err = pq.Peek()
+		assert.Error(t, err)
+	})
+
+	t.Run("修改和删除元素", func(t *testing.T) {
+		pq := NewIndexedPriorityQueueFunc(byTime)
+		a := pq.Enqueue(&task{"a", base.Add(time.Hour)})
+		b := pq.Enqueue(&task{"b", base.Add(2 * time.Hour)})
+		c := pq.Enqueue(&task{"c", base.Add(3 * time.Hour)})
+
+		// 原处修改指针元素后传入同一个指针
+		a.Value().at = base.Add(10 * time.Hour)
+		assert.NoError(t, pq.Update(a, a.Value()))
+		val, _ := pq.Peek()
+		assert.Equal(t, "b", val.name)
+
+		// 替换为新的元素
+		assert.NoError(t, pq.Update(c, &task{"c2", base}))
+		val, _ = pq.Peek()
+		assert.Equal(t, "c2", val.name)
+
+		assert.NoError(t, pq.Remove(b))
+		assert.Error(t, pq.Remove(b))
+		assert.Error(t, pq.Update(b, &task{}))
+		assert.Error(t, pq.Remove(nil))
+
+		var order []string
+		for !pq.IsEmpty() {
+			v, _ := pq.Dequeue()
+			order = append(order, v.name)
+		}
+		assert.Equal(t, []string{"c2", "a"}, order)
+	})
+
+	t.Run("清空", func(t *testing.T) {
+		pq := NewIndexedPriorityQueueFunc(byTime)
+		h := pq.Enqueue(&task{"a", base})
+
+		pq.Clear()
+		assert.True(t, pq.IsEmpty())
+		assert.False(t, pq.Contains(h))
+		assert.Empty(t, pq.ToSlice())
+	})
+
+	t.Run("随机操作", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		pq := NewIndexedPriorityQueueFunc(func(a, b int) int { return a - b })
+		live := make(map[*FuncHandle[int]]bool)
+
+		for i := 0; i < 2000; i++ {
+			switch r.Intn(4) {
+			case 0, 1:
+				live[pq.Enqueue(r.Intn(100))] = true
+			case 2:
+				for h := range live {
+					assert.NoError(t, pq.Update(h, r.Intn(100)))
+					break
+				}
+			case 3:
+				for h := range live {
+					assert.NoError(t, pq.Remove(h))
+					delete(live, h)
+					break
+				}
+			}
+		}
+
+		assert.Equal(t, len(live), pq.Size())
+		prev := -1
+		for !pq.IsEmpty() {
+			v, err := pq.Dequeue()
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, v, prev)
+			prev = v
+		}
+	})
+
+	t.Run("比较函数为nil", func(t *testing.T) {
+		assert.Panics(t, func() { NewIndexedPriorityQueueFunc[int](nil) })
+	})
+}
@@ -1,6 +1,9 @@
 package queue
 
-import "errors"
+import (
+	"errors"
+	"iter"
+)
 
 // PriorityItem 优先级队列中的元素
 type PriorityItem[T any] struct {
@@ -87,44 +90,55 @@ func (pq *PriorityQueue[T]) ToSlice() []PriorityItem[T] {
 	return result
 }
 
-// siftUp 向上堆化（插入时使用）
-func (pq *PriorityQueue[T]) siftUp(index int) {
-	for index > 0 {
-		parent := (index - 1) / 2
-
-		// 如果当前元素优先级更高（数值更小），交换
-		if pq.items[index].Priority < pq.items[parent].Priority {
-			pq.items[index], pq.items[parent] = pq.items[parent], pq.items[index]
-			index = parent
-		} else {
-			break
+// All 返回遍历元素和优先级的迭代器，按堆中的顺序，不改变队列
+func (pq *PriorityQueue[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for _, item := range pq.items {
+			if !yield(item.Value, item.Priority) {
+				return
+			}
+		}
+	}
+}
+
+// Drain 返回按优先级依次出队的迭代器，每迭代一个元素就将其从队列中移除
+// 提前结束迭代时，未迭代的元素保留在队列中
+func (pq *PriorityQueue[T]) Drain() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for !pq.IsEmpty() {
+			value, priority, _ := pq.Dequeue()
+			if !yield(value, priority) {
+				return
+			}
 		}
 	}
 }
 
+// CollectPriorityQueue 从产出元素和优先级的迭代器创建优先级队列
+func CollectPriorityQueue[T any](seq iter.Seq2[T, int]) *PriorityQueue[T] {
+	pq := NewPriorityQueue[T]()
+	for value, priority := range seq {
+		pq.Enqueue(value, priority)
+	}
+	return pq
+}
+
+// siftUp 向上堆化（插入时使用）
+func (pq *PriorityQueue[T]) siftUp(index int) {
+	siftUp(index, pq.less, pq.swap)
+}
+
 // siftDown 向下堆化（删除时使用）
 func (pq *PriorityQueue[T]) siftDown(index int) {
-	length := len(pq.items)
-	for {
-		left := 2*index + 1
-		right := 2*index + 2
-		smallest := index
-
-		// 找到子节点中优先级更高的
-		if left < length && pq.items[left].Priority < pq.items[smallest].Priority {
-			smallest = left
-		}
-		if right < length && pq.items[right].Priority < pq.items[smallest].Priority {
-			smallest = right
-		}
+	siftDown(index, len(pq.items), pq.less, pq.swap)
+}
 
-		// 如果根节点已经是优先级最高的，停止
-		if smallest == index {
-			break
-		}
+// less 比较两个元素的优先级，数值越小优先级越高
+func (pq *PriorityQueue[T]) less(i, j int) bool {
+	return pq.items[i].Priority < pq.items[j].Priority
+}
 
-		// 交换元素
-		pq.items[index], pq.items[smallest] = pq.items[smallest], pq.items[index]
-		index = smallest
-	}
+// swap 交换两个元素
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
 }
@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"maps"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -162,3 +163,27 @@ func TestPriorityQueue(t *testing.T) {
 		assert.Equal(t, "normal", val)
 	})
 }
+
+func TestPriorityQueueIter(t *testing.T) {
+	pq := CollectPriorityQueue(maps.All(map[string]int{"c": 3, "a": 1, "b": 2}))
+
+	seen := maps.Collect(pq.All())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, seen)
+	assert.Equal(t, 3, pq.Size())
+
+	var values []string
+	for v, p := range pq.Drain() {
+		values = append(values, v)
+		if p == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, values)
+	// 提前结束时剩余元素保留在队列中
+	assert.Equal(t, 1, pq.Size())
+
+	for v := range pq.Drain() {
+		assert.Equal(t, "c", v)
+	}
+	assert.True(t, pq.IsEmpty())
+}
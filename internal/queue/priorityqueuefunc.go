@@ -0,0 +1,124 @@
+package queue
+
+import "errors"
+
+// funcEntry 自定义比较优先级队列中的元素
+type funcEntry[T any] struct {
+	value T
+	seq   uint64 // 入队序号，用于稳定排序
+}
+
+// PriorityQueueFunc 使用自定义比较函数的优先级队列 - 基于二叉堆实现
+// cmp(a, b) < 0 表示a优先于b，可用于最大堆、浮点优先级或组合键等场景
+type PriorityQueueFunc[T any] struct {
+	items  []funcEntry[T]
+	cmp    func(a, b T) int
+	stable bool
+	seq    uint64
+}
+
+// NewPriorityQueueFunc 创建使用比较函数cmp的优先级队列
+// cmp(a, b) 返回负数表示a优先于b，0表示优先级相同；cmp为nil时panic
+func NewPriorityQueueFunc[T any](cmp func(a, b T) int) *PriorityQueueFunc[T] {
+	if cmp == nil {
+		panic("queue: 比较函数不能为nil")
+	}
+	return &PriorityQueueFunc[T]{
+		items: make([]funcEntry[T], 0),
+		cmp:   cmp,
+	}
+}
+
+// NewStablePriorityQueueFunc 创建稳定的优先级队列
+// 优先级相同的元素按入队顺序（FIFO）出队
+func NewStablePriorityQueueFunc[T any](cmp func(a, b T) int) *PriorityQueueFunc[T] {
+	pq := NewPriorityQueueFunc(cmp)
+	pq.stable = true
+	return pq
+}
+
+// Enqueue 入队
+func (pq *PriorityQueueFunc[T]) Enqueue(value T) {
+	pq.items = append(pq.items, funcEntry[T]{value: value, seq: pq.seq})
+	pq.seq++
+	pq.siftUp(len(pq.items) - 1)
+}
+
+// Dequeue 出队 - 移除并返回优先级最高的元素
+func (pq *PriorityQueueFunc[T]) Dequeue() (T, error) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+
+	value := pq.items[0].value
+
+	// 将最后一个元素移到根节点
+	lastIndex := len(pq.items) - 1
+	pq.items[0] = pq.items[lastIndex]
+	pq.items[lastIndex] = funcEntry[T]{}
+	pq.items = pq.items[:lastIndex]
+
+	if len(pq.items) > 0 {
+		pq.siftDown(0)
+	}
+	return value, nil
+}
+
+// Peek 查看优先级最高的元素
+func (pq *PriorityQueueFunc[T]) Peek() (T, error) {
+	if pq.IsEmpty() {
+		var zero T
+		return zero, errors.New("priority queue is empty")
+	}
+	return pq.items[0].value, nil
+}
+
+// Size 返回队列中的元素数量
+func (pq *PriorityQueueFunc[T]) Size() int {
+	return len(pq.items)
+}
+
+// IsEmpty 检查队列是否为空
+func (pq *PriorityQueueFunc[T]) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+// Clear 清空队列
+func (pq *PriorityQueueFunc[T]) Clear() {
+	clear(pq.items)
+	pq.items = pq.items[:0]
+	pq.seq = 0
+}
+
+// ToSlice 转换为切片（堆中的顺序）
+func (pq *PriorityQueueFunc[T]) ToSlice() []T {
+	result := make([]T, len(pq.items))
+	for i, entry := range pq.items {
+		result[i] = entry.value
+	}
+	return result
+}
+
+// siftUp 向上堆化
+func (pq *PriorityQueueFunc[T]) siftUp(index int) {
+	siftUp(index, pq.less, pq.swap)
+}
+
+// siftDown 向下堆化
+func (pq *PriorityQueueFunc[T]) siftDown(index int) {
+	siftDown(index, len(pq.items), pq.less, pq.swap)
+}
+
+// less 使用比较函数判断优先级，稳定模式下优先级相同时先入队的优先
+func (pq *PriorityQueueFunc[T]) less(i, j int) bool {
+	if c := pq.cmp(pq.items[i].value, pq.items[j].value); c != 0 {
+		return c < 0
+	}
+	return pq.stable && pq.items[i].seq < pq.items[j].seq
+}
+
+// swap 交换两个元素
+func (pq *PriorityQueueFunc[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+}
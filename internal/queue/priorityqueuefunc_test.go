@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueueFunc(t *testing.T) {
+	t.Run("最大堆", func(t *testing.T) {
+		pq := NewPriorityQueueFunc(func(a, b int) int {
+			return cmp.Compare(b, a)
+		})
+
+		for _, v := range []int{3, 1, 4, 1, 5, 9, 2, 6} {
+			pq.Enqueue(v)
+		}
+		assert.Equal(t, 8, pq.Size())
+
+		val, err := pq.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, 9, val)
+
+		var got []int
+		for !pq.IsEmpty() {
+			v, err := pq.Dequeue()
+			assert.NoError(t, err)
+			got = append(got, v)
+		}
+		assert.Equal(t, []int{9, 6, 5, 4, 3, 2, 1, 1}, got)
+	})
+
+	t.Run("浮点优先级", func(t *testing.T) {
+		type job struct {
+			name   string
+			weight float64
+		}
+		pq := NewPriorityQueueFunc(func(a, b job) int {
+			return cmp.Compare(a.weight, b.weight)
+		})
+
+		pq.Enqueue(job{"b", 0.5})
+		pq.Enqueue(job{"c", 1.25})
+		pq.Enqueue(job{"a", 0.1})
+
+		var got []string
+		for !pq.IsEmpty() {
+			j, _ := pq.Dequeue()
+			got = append(got, j.name)
+		}
+		assert.Equal(t, []string{"a", "b", "c"}, got)
+	})
+
+	t.Run("组合键", func(t *testing.T) {
+		type task struct {
+			level int
+			name  string
+		}
+		pq := NewPriorityQueueFunc(func(a, b task) int {
+			return cmp.Or(cmp.Compare(a.level, b.level), cmp.Compare(a.name, b.name))
+		})
+
+		pq.Enqueue(task{2, "x"})
+		pq.Enqueue(task{1, "b"})
+		pq.Enqueue(task{1, "a"})
+		pq.Enqueue(task{2, "a"})
+
+		var got []task
+		for !pq.IsEmpty() {
+			v, _ := pq.Dequeue()
+			got = append(got, v)
+		}
+		assert.Equal(t, []task{{1, "a"}, {1, "b"}, {2, "a"}, {2, "x"}}, got)
+	})
+
+	t.Run("稳定排序", func(t *testing.T) {
+		type job struct {
+			priority int
+			id       int
+		}
+		pq := NewStablePriorityQueueFunc(func(a, b job) int {
+			return cmp.Compare(a.priority, b.priority)
+		})
+
+		for i := 0; i < 100; i++ {
+			pq.Enqueue(job{priority: i % 3, id: i})
+		}
+
+		lastID := map[int]int{0: -1, 1: -1, 2: -1}
+		lastPriority := 0
+		for !pq.IsEmpty() {
+			j, err := pq.Dequeue()
+			assert.NoError(t, err)
+			assert.GreaterOrEqual(t, j.priority, lastPriority)
+			// 相同优先级按入队顺序出队
+			assert.Greater(t, j.id, lastID[j.priority])
+			lastID[j.priority] = j.id
+			lastPriority = j.priority
+		}
+	})
+
+	t.Run("错误情况和清空", func(t *testing.T) {
+		pq := NewPriorityQueueFunc(cmp.Compare[string])
+
+		_, err := pq.Dequeue()
+		assert.Error(t, err)
+		_, err = pq.Peek()
+		assert.Error(t, err)
+
+		pq.Enqueue("b")
+		pq.Enqueue("a")
+		assert.ElementsMatch(t, []string{"a", "b"}, pq.ToSlice())
+
+		pq.Clear()
+		assert.True(t, pq.IsEmpty())
+		assert.Equal(t, 0, pq.Size())
+	})
+
+	t.Run("比较函数为nil", func(t *testing.T) {
+		assert.Panics(t, func() { NewPriorityQueueFunc[int](nil) })
+		assert.Panics(t, func() { NewStablePriorityQueueFunc[int](nil) })
+	})
+}
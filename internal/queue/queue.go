@@ -2,23 +2,76 @@ package queue
 
 import (
 	"errors"
+	"iter"
+)
+
+// minQueueCapacity 环形缓冲区的最小容量
+const minQueueCapacity = 8
+
+// OverflowPolicy 有界队列满时的处理策略
+type OverflowPolicy int
+
+const (
+	// RejectNew 拒绝新元素，Offer返回false，Enqueue静默丢弃新元素
+	RejectNew OverflowPolicy = iota
+	// OverwriteOldest 覆盖最旧的元素
+	OverwriteOldest
 )
 
 // Queue 普通队列 - FIFO (先进先出)
+// 基于环形缓冲区实现，入队和出队均为O(1)，元素减少时自动收缩容量
 type Queue[T any] struct {
-	items []T
+	items    []T // 环形缓冲区
+	head     int // 队首元素下标
+	length   int
+	capacity int // 队列容量上限，0表示不限
+	policy   OverflowPolicy
 }
 
 // NewQueue 创建新的队列
 func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{}
+}
+
+// NewBoundedQueue 创建有界队列
+// capacity 为队列容量上限，policy 决定队列满时的处理方式
+// RejectNew策略下队列已满时Enqueue会静默丢弃新元素且没有任何提示，需要感知拒绝时使用Offer
+func NewBoundedQueue[T any](capacity int, policy OverflowPolicy) *Queue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
 	return &Queue[T]{
-		items: make([]T, 0),
+		capacity: capacity,
+		policy:   policy,
 	}
 }
 
 // Enqueue 入队 - 添加元素到队列尾部
+// 注意：RejectNew策略的有界队列已满时，新元素会被静默丢弃，不返回错误也不阻塞；
+// 需要感知拒绝时使用Offer，需要在队列满时等待时使用BlockingQueue
 func (q *Queue[T]) Enqueue(value T) {
-	q.items = append(q.items, value)
+	q.Offer(value)
+}
+
+// Offer 入队 - 添加元素到队列尾部，返回元素是否入队
+// 有界队列已满时，RejectNew策略返回false，OverwriteOldest策略覆盖队首元素并返回true
+func (q *Queue[T]) Offer(value T) bool {
+	if q.IsFull() {
+		if q.policy != OverwriteOldest {
+			return false
+		}
+		// 缓冲区已满时队尾的下一个位置就是队首
+		q.items[q.head] = value
+		q.head = q.index(1)
+		return true
+	}
+
+	if q.length == len(q.items) {
+		q.grow()
+	}
+	q.items[q.index(q.length)] = value
+	q.length++
+	return true
 }
 
 // Dequeue 出队 - 从队列头部移除并返回元素
@@ -29,8 +82,13 @@ func (q *Queue[T]) Dequeue() (T, error) {
 		return zero, errors.New("queue is empty")
 	}
 
-	value := q.items[0]
-	q.items = q.items[1:]
+	value := q.items[q.head]
+	var zero T
+	q.items[q.head] = zero // 释放引用，便于GC回收
+	q.head = q.index(1)
+	q.length--
+
+	q.maybeShrink()
 	return value, nil
 }
 
@@ -42,27 +100,113 @@ func (q *Queue[T]) Peek() (T, error) {
 		return zero, errors.New("queue is empty")
 	}
 
-	return q.items[0], nil
+	return q.items[q.head], nil
 }
 
 // Size 返回队列中的元素数量
 func (q *Queue[T]) Size() int {
-	return len(q.items)
+	return q.length
 }
 
 // IsEmpty 检查队列是否为空
 func (q *Queue[T]) IsEmpty() bool {
-	return len(q.items) == 0
+	return q.length == 0
+}
+
+// IsFull 检查有界队列是否已满，无界队列总是返回false
+func (q *Queue[T]) IsFull() bool {
+	return q.capacity > 0 && q.length >= q.capacity
 }
 
-// Clear 清空队列
+// Capacity 返回队列容量上限，0表示不限
+func (q *Queue[T]) Capacity() int {
+	return q.capacity
+}
+
+// Clear 清空队列并释放缓冲区
 func (q *Queue[T]) Clear() {
-	q.items = q.items[:0]
+	q.items = nil
+	q.head = 0
+	q.length = 0
 }
 
 // ToSlice 转换为切片
 func (q *Queue[T]) ToSlice() []T {
-	result := make([]T, len(q.items))
-	copy(result, q.items)
+	result := make([]T, q.length)
+	q.copyTo(result)
 	return result
 }
+
+// All 返回从队首到队尾的迭代器，产出偏移量和元素
+func (q *Queue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < q.length; i++ {
+			if !yield(i, q.items[q.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回从队尾到队首的迭代器，产出偏移量和元素
+func (q *Queue[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := q.length - 1; i >= 0; i-- {
+			if !yield(i, q.items[q.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// CollectQueue 从迭代器创建队列，元素按迭代顺序入队
+func CollectQueue[T any](seq iter.Seq[T]) *Queue[T] {
+	q := NewQueue[T]()
+	for v := range seq {
+		q.Enqueue(v)
+	}
+	return q
+}
+
+// index 返回从队首偏移offset的元素在缓冲区中的下标
+func (q *Queue[T]) index(offset int) int {
+	return (q.head + offset) % len(q.items)
+}
+
+// grow 扩容（内部方法）
+func (q *Queue[T]) grow() {
+	newCapacity := len(q.items) * 2
+	if newCapacity < minQueueCapacity {
+		newCapacity = minQueueCapacity
+	}
+	// 有界队列的缓冲区不超过容量上限
+	if q.capacity > 0 && newCapacity > q.capacity {
+		newCapacity = q.capacity
+	}
+	q.resize(newCapacity)
+}
+
+// maybeShrink 检查是否需要收缩容量（内部方法）
+func (q *Queue[T]) maybeShrink() {
+	// 当元素数量小于容量的1/4时，收缩为一半
+	if len(q.items) > minQueueCapacity && q.length < len(q.items)/4 {
+		q.resize(len(q.items) / 2)
+	}
+}
+
+// resize 重新分配缓冲区，元素从下标0开始连续存放
+func (q *Queue[T]) resize(capacity int) {
+	newItems := make([]T, capacity)
+	q.copyTo(newItems)
+	q.items = newItems
+	q.head = 0
+}
+
+// copyTo 按从队首到队尾的顺序将元素复制到dst
+func (q *Queue[T]) copyTo(dst []T) {
+	if q.length == 0 {
+		return
+	}
+	n := copy(dst, q.items[q.head:min(q.head+q.length, len(q.items))])
+	copy(dst[n:], q.items[:q.length-n])
+}
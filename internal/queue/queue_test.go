@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -107,3 +108,151 @@ func TestQueue(t *testing.T) {
 		assert.Equal(t, "banana", val)
 	})
 }
+
+func TestQueueRingBuffer(t *testing.T) {
+	t.Run("环绕读写", func(t *testing.T) {
+		q := NewQueue[int]()
+
+		// 交替入队出队，使队首在缓冲区中循环移动
+		next := 0
+		for round := 0; round < 100; round++ {
+			for i := 0; i < 5; i++ {
+				q.Enqueue(round*5 + i)
+			}
+			for i := 0; i < 4; i++ {
+				val, err := q.Dequeue()
+				assert.NoError(t, err)
+				assert.Equal(t, next, val)
+				next++
+			}
+		}
+		assert.Equal(t, 100, q.Size())
+
+		slice := q.ToSlice()
+		for i, v := range slice {
+			assert.Equal(t, next+i, v)
+		}
+	})
+
+	t.Run("自动收缩", func(t *testing.T) {
+		q := NewQueue[int]()
+		for i := 0; i < 1000; i++ {
+			q.Enqueue(i)
+		}
+		grown := len(q.items)
+		assert.GreaterOrEqual(t, grown, 1000)
+
+		for i := 0; i < 995; i++ {
+			val, err := q.Dequeue()
+			assert.NoError(t, err)
+			assert.Equal(t, i, val)
+		}
+		assert.Less(t, len(q.items), grown/8)
+		assert.Equal(t, []int{995, 996, 997, 998, 999}, q.ToSlice())
+	})
+}
+
+func TestBoundedQueue(t *testing.T) {
+	t.Run("拒绝新元素", func(t *testing.T) {
+		q := NewBoundedQueue[int](3, RejectNew)
+		assert.Equal(t, 3, q.Capacity())
+
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Enqueue(3)
+		assert.True(t, q.IsFull())
+
+		assert.False(t, q.Offer(4))
+		assert.Equal(t, []int{1, 2, 3}, q.ToSlice())
+
+		val, err := q.Dequeue()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+		assert.False(t, q.IsFull())
+		assert.True(t, q.Offer(4))
+		assert.Equal(t, []int{2, 3, 4}, q.ToSlice())
+	})
+
+	t.Run("覆盖最旧元素", func(t *testing.T) {
+		q := NewBoundedQueue[int](3, OverwriteOldest)
+
+		for i := 1; i <= 7; i++ {
+			assert.True(t, q.Offer(i))
+		}
+		assert.Equal(t, 3, q.Size())
+		assert.Equal(t, []int{5, 6, 7}, q.ToSlice())
+
+		val, err := q.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, 5, val)
+
+		val, err = q.Dequeue()
+		assert.NoError(t, err)
+		assert.Equal(t, 5, val)
+		q.Enqueue(8)
+		assert.Equal(t, []int{6, 7, 8}, q.ToSlice())
+	})
+
+	t.Run("拒绝策略下Enqueue丢弃新元素", func(t *testing.T) {
+		q := NewBoundedQueue[int](2, RejectNew)
+		q.Enqueue(1)
+		q.Enqueue(2)
+		q.Enqueue(3)
+		q.Enqueue(4)
+
+		assert.Equal(t, 2, q.Size())
+		assert.Equal(t, []int{1, 2}, q.ToSlice())
+	})
+
+	t.Run("大容量有界队列按需扩容", func(t *testing.T) {
+		q := NewBoundedQueue[int](1000, OverwriteOldest)
+		for i := 0; i < 10; i++ {
+			q.Enqueue(i)
+		}
+		assert.Less(t, len(q.items), 1000)
+
+		for i := 10; i < 2000; i++ {
+			q.Enqueue(i)
+		}
+		assert.Equal(t, 1000, len(q.items))
+		assert.Equal(t, 1000, q.Size())
+
+		val, err := q.Peek()
+		assert.NoError(t, err)
+		assert.Equal(t, 1000, val)
+	})
+
+	t.Run("非法容量", func(t *testing.T) {
+		q := NewBoundedQueue[int](0, RejectNew)
+		assert.Equal(t, 1, q.Capacity())
+		q.Enqueue(1)
+		assert.False(t, q.Offer(2))
+		q.Enqueue(3)
+		assert.Equal(t, []int{1}, q.ToSlice())
+	})
+}
+
+func TestQueueIter(t *testing.T) {
+	q := CollectQueue(slices.Values([]int{1, 2, 3}))
+	// 出队再入队使环形缓冲区回绕
+	_, _ = q.Dequeue()
+	q.Enqueue(4)
+
+	var offsets, values []int
+	for i, v := range q.All() {
+		offsets = append(offsets, i)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, offsets)
+	assert.Equal(t, []int{2, 3, 4}, values)
+
+	values = values[:0]
+	for _, v := range q.Backward() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{4, 3, 2}, values)
+	assert.Equal(t, 3, q.Size())
+
+	d := CollectDeque(slices.Values([]string{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, d.ToSlice())
+}
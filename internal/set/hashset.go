@@ -1,5 +1,7 @@
 package set
 
+import "iter"
+
 // HashSet 基于map实现的哈希集合
 type HashSet[T comparable] struct {
 	items map[T]struct{} // 使用空结构体节省内存
@@ -54,6 +56,26 @@ func (s *HashSet[T]) ToSlice() []T {
 	return items
 }
 
+// All 返回遍历集合元素的迭代器，顺序不确定
+func (s *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// CollectHashSet 从迭代器创建HashSet
+func CollectHashSet[T comparable](seq iter.Seq[T]) *HashSet[T] {
+	s := NewHashSet[T]()
+	for item := range seq {
+		s.Add(item)
+	}
+	return s
+}
+
 // Union 返回两个集合的并集
 func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
 	result := NewHashSet[T]()
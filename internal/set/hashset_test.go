@@ -1,6 +1,7 @@
 package set
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -133,3 +134,19 @@ func TestHashSetDifference(t *testing.T) {
 	assert.False(t, diff.Contains(3))
 	assert.False(t, diff.Contains(4))
 }
+
+func TestHashSetIter(t *testing.T) {
+	s := CollectHashSet(slices.Values([]int{3, 1, 2, 3}))
+	assert.Equal(t, 3, s.Size())
+
+	got := slices.Collect(s.All())
+	slices.Sort(got)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+}
@@ -2,55 +2,58 @@ package set
 
 import (
 	"cmp"
-	"sort"
+	"iter"
+	"slices"
 )
 
 // TreeSet 基于排序切片实现的有序集合
-// T必须是可比较且可排序的类型
-type TreeSet[T cmp.Ordered] struct {
-	items []T
+// 元素按比较函数排序，比较结果为0的元素视为相同
+type TreeSet[T any] struct {
+	items   []T
+	compare func(a, b T) int
 }
 
 // NewTreeSet 创建新的TreeSet
 func NewTreeSet[T cmp.Ordered]() *TreeSet[T] {
+	return NewTreeSetFunc(cmp.Compare[T])
+}
+
+// NewTreeSetFunc 创建使用比较函数compare排序的TreeSet
+// compare(a, b) 返回负数表示a排在b之前，0表示两个元素相同；compare为nil时panic
+func NewTreeSetFunc[T any](compare func(a, b T) int) *TreeSet[T] {
+	if compare == nil {
+		panic("set: 比较函数不能为nil")
+	}
 	return &TreeSet[T]{
-		items: make([]T, 0),
+		items:   make([]T, 0),
+		compare: compare,
 	}
 }
 
 // Add 添加元素到集合，自动保持有序
 func (s *TreeSet[T]) Add(item T) {
+	index, found := slices.BinarySearchFunc(s.items, item, s.compare)
 	// 如果元素已存在，不添加
-	if s.Contains(item) {
+	if found {
 		return
 	}
 
-	// 插入元素并保持有序
-	s.items = append(s.items, item)
-	sort.Slice(s.items, func(i, j int) bool {
-		return s.items[i] < s.items[j]
-	})
+	// 插入到有序位置
+	s.items = slices.Insert(s.items, index, item)
 }
 
 // Remove 从集合中删除元素
 func (s *TreeSet[T]) Remove(item T) {
-	index := sort.Search(len(s.items), func(i int) bool {
-		return s.items[i] >= item
-	})
-
-	// 找到精确匹配的元素
-	if index < len(s.items) && s.items[index] == item {
-		s.items = append(s.items[:index], s.items[index+1:]...)
+	index, found := slices.BinarySearchFunc(s.items, item, s.compare)
+	if found {
+		s.items = slices.Delete(s.items, index, index+1)
 	}
 }
 
 // Contains 检查元素是否在集合中
 func (s *TreeSet[T]) Contains(item T) bool {
-	index := sort.Search(len(s.items), func(i int) bool {
-		return s.items[i] >= item
-	})
-
-	return index < len(s.items) && s.items[index] == item
+	_, found := slices.BinarySearchFunc(s.items, item, s.compare)
+	return found
 }
 
 // Size 返回集合大小
@@ -75,17 +78,62 @@ func (s *TreeSet[T]) ToSlice() []T {
 	return result
 }
 
+// All 返回按升序遍历的迭代器
+func (s *TreeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回按降序遍历的迭代器
+func (s *TreeSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Range 返回[from, to)范围内元素的升序迭代器
+func (s *TreeSet[T]) Range(from, to T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		start, _ := slices.BinarySearchFunc(s.items, from, s.compare)
+		for _, item := range s.items[start:] {
+			if s.compare(item, to) >= 0 || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// CollectTreeSet 从迭代器创建TreeSet
+func CollectTreeSet[T cmp.Ordered](seq iter.Seq[T]) *TreeSet[T] {
+	s := NewTreeSet[T]()
+	for item := range seq {
+		s.Add(item)
+	}
+	return s
+}
+
 // Union 返回两个集合的并集
+// other必须使用与s相同的比较函数，否则按有序切片合并的结果不正确
 func (s *TreeSet[T]) Union(other *TreeSet[T]) *TreeSet[T] {
-	result := NewTreeSet[T]()
+	result := NewTreeSetFunc(s.compare)
 
 	// 合并两个有序切片
 	i, j := 0, 0
 	for i < len(s.items) && j < len(other.items) {
-		if s.items[i] < other.items[j] {
+		c := s.compare(s.items[i], other.items[j])
+		if c < 0 {
 			result.Add(s.items[i])
 			i++
-		} else if s.items[i] > other.items[j] {
+		} else if c > 0 {
 			result.Add(other.items[j])
 			j++
 		} else {
@@ -110,15 +158,17 @@ func (s *TreeSet[T]) Union(other *TreeSet[T]) *TreeSet[T] {
 }
 
 // Intersect 返回两个集合的交集
+// other必须使用与s相同的比较函数，否则按有序切片合并的结果不正确
 func (s *TreeSet[T]) Intersect(other *TreeSet[T]) *TreeSet[T] {
-	result := NewTreeSet[T]()
+	result := NewTreeSetFunc(s.compare)
 
 	// 双指针遍历两个有序切片
 	i, j := 0, 0
 	for i < len(s.items) && j < len(other.items) {
-		if s.items[i] < other.items[j] {
+		c := s.compare(s.items[i], other.items[j])
+		if c < 0 {
 			i++
-		} else if s.items[i] > other.items[j] {
+		} else if c > 0 {
 			j++
 		} else {
 			// 元素相等，加入结果
@@ -132,16 +182,18 @@ func (s *TreeSet[T]) Intersect(other *TreeSet[T]) *TreeSet[T] {
 }
 
 // Difference 返回两个集合的差集 (s - other)
+// other必须使用与s相同的比较函数，否则按有序切片合并的结果不正确
 func (s *TreeSet[T]) Difference(other *TreeSet[T]) *TreeSet[T] {
-	result := NewTreeSet[T]()
+	result := NewTreeSetFunc(s.compare)
 
 	// 双指针遍历
 	i, j := 0, 0
 	for i < len(s.items) && j < len(other.items) {
-		if s.items[i] < other.items[j] {
+		c := s.compare(s.items[i], other.items[j])
+		if c < 0 {
 			result.Add(s.items[i])
 			i++
-		} else if s.items[i] > other.items[j] {
+		} else if c > 0 {
 			j++
 		} else {
 			// 元素相等，跳过
@@ -1,7 +1,10 @@
 package set
 
 import (
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -137,3 +140,50 @@ func TestTreeSetDifference(t *testing.T) {
 	assert.Equal(t, 1, diff.Size())
 	assert.Equal(t, []int{1}, diff.ToSlice())
 }
+
+func TestTreeSetFunc(t *testing.T) {
+	t.Run("time.Time元素", func(t *testing.T) {
+		base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		s := NewTreeSetFunc(func(a, b time.Time) int {
+			return a.Compare(b)
+		})
+
+		s.Add(base.Add(time.Hour))
+		s.Add(base)
+		s.Add(base.Add(time.Hour))
+		assert.Equal(t, []time.Time{base, base.Add(time.Hour)}, s.ToSlice())
+		assert.True(t, s.Contains(base))
+		s.Remove(base)
+		assert.False(t, s.Contains(base))
+	})
+
+	t.Run("忽略大小写的集合运算", func(t *testing.T) {
+		ci := func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		}
+		s1 := NewTreeSetFunc(ci)
+		s1.Add("Go")
+		s1.Add("rust")
+		s2 := NewTreeSetFunc(ci)
+		s2.Add("GO")
+		s2.Add("java")
+
+		assert.Equal(t, []string{"Go", "java", "rust"}, s1.Union(s2).ToSlice())
+		assert.Equal(t, []string{"Go"}, s1.Intersect(s2).ToSlice())
+		assert.Equal(t, []string{"rust"}, s1.Difference(s2).ToSlice())
+	})
+
+	t.Run("比较函数为nil", func(t *testing.T) {
+		assert.Panics(t, func() { NewTreeSetFunc[int](nil) })
+	})
+}
+
+func TestTreeSetIter(t *testing.T) {
+	s := CollectTreeSet(slices.Values([]int{5, 1, 4, 2, 3, 1}))
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slices.Collect(s.All()))
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, slices.Collect(s.Backward()))
+	assert.Equal(t, []int{2, 3}, slices.Collect(s.Range(2, 4)))
+	assert.Equal(t, []int{4, 5}, slices.Collect(s.Range(4, 10)))
+	assert.Empty(t, slices.Collect(s.Range(6, 10)))
+}
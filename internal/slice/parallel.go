@@ -0,0 +1,209 @@
+package slice
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelOptions 并行操作的配置
+type ParallelOptions struct {
+	// Workers 并发的goroutine数量，小于1时使用runtime.GOMAXPROCS(0)
+	Workers int
+	// ChunkSize 每个任务处理的元素数量，小于1时按每个worker约4个任务自动计算
+	ChunkSize int
+}
+
+// ParallelMap 并行地将每个元素映射为新值，结果顺序与src一致
+// ctx取消时在分块边界停止，返回nil和ctx.Err()
+func ParallelMap[T any, R any](ctx context.Context, src []T, mapper func(T) R, opts ...ParallelOptions) ([]R, error) {
+	return ParallelMapErr(ctx, src, func(item T) (R, error) {
+		return mapper(item), nil
+	}, opts...)
+}
+
+// ParallelMapErr 与ParallelMap相同，mapper返回错误时停止并返回第一个错误
+func ParallelMapErr[T any, R any](ctx context.Context, src []T, mapper func(T) (R, error), opts ...ParallelOptions) ([]R, error) {
+	result := make([]R, len(src))
+	err := parallelChunks(ctx, len(src), opts, func(_, start, end int) error {
+		for i := start; i < end; i++ {
+			value, err := mapper(src[i])
+			if err != nil {
+				return err
+			}
+			result[i] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParallelFilter 并行地过滤元素，结果顺序与src一致
+// ctx取消时在分块边界停止，返回nil和ctx.Err()
+func ParallelFilter[T any](ctx context.Context, src []T, predicate func(T) bool, opts ...ParallelOptions) ([]T, error) {
+	return ParallelFilterErr(ctx, src, func(item T) (bool, error) {
+		return predicate(item), nil
+	}, opts...)
+}
+
+// ParallelFilterErr 与ParallelFilter相同，predicate返回错误时停止并返回第一个错误
+func ParallelFilterErr[T any](ctx context.Context, src []T, predicate func(T) (bool, error), opts ...ParallelOptions) ([]T, error) {
+	_, chunks := chunkLayout(len(src), opts)
+	kept := make([][]T, chunks)
+	err := parallelChunks(ctx, len(src), opts, func(chunk, start, end int) error {
+		for i := start; i < end; i++ {
+			ok, err := predicate(src[i])
+			if err != nil {
+				return err
+			}
+			if ok {
+				kept[chunk] = append(kept[chunk], src[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []T
+	for _, part := range kept {
+		result = append(result, part...)
+	}
+	return result, nil
+}
+
+// ParallelForEach 并行地对每个元素执行fn，执行顺序不确定
+// ctx取消时在分块边界停止并返回ctx.Err()
+func ParallelForEach[T any](ctx context.Context, src []T, fn func(T), opts ...ParallelOptions) error {
+	return ParallelForEachErr(ctx, src, func(item T) error {
+		fn(item)
+		return nil
+	}, opts...)
+}
+
+// ParallelForEachErr 与ParallelForEach相同，fn返回错误时停止并返回第一个错误
+func ParallelForEachErr[T any](ctx context.Context, src []T, fn func(T) error, opts ...ParallelOptions) error {
+	return parallelChunks(ctx, len(src), opts, func(_, start, end int) error {
+		for i := start; i < end; i++ {
+			if err := fn(src[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ParallelReduce 并行地将切片聚合为一个值
+// 每个分块从initial开始用reducer聚合，再按分块顺序用combiner合并
+// combiner必须满足结合律，initial必须是combiner的单位元，例如求和时为0
+// ctx取消时在分块边界停止，返回initial和ctx.Err()
+func ParallelReduce[T any, R any](ctx context.Context, src []T, reducer func(R, T) R, combiner func(R, R) R, initial R, opts ...ParallelOptions) (R, error) {
+	return ParallelReduceErr(ctx, src, func(acc R, item T) (R, error) {
+		return reducer(acc, item), nil
+	}, combiner, initial, opts...)
+}
+
+// ParallelReduceErr 与ParallelReduce相同，reducer返回错误时停止所有分块并返回initial和第一个错误
+func ParallelReduceErr[T any, R any](ctx context.Context, src []T, reducer func(R, T) (R, error), combiner func(R, R) R, initial R, opts ...ParallelOptions) (R, error) {
+	_, chunks := chunkLayout(len(src), opts)
+	partials := make([]R, chunks)
+	err := parallelChunks(ctx, len(src), opts, func(chunk, start, end int) error {
+		acc := initial
+		for i := start; i < end; i++ {
+			var err error
+			if acc, err = reducer(acc, src[i]); err != nil {
+				return err
+			}
+		}
+		partials[chunk] = acc
+		return nil
+	})
+	if err != nil {
+		return initial, err
+	}
+
+	result := initial
+	for _, partial := range partials {
+		result = combiner(result, partial)
+	}
+	return result, nil
+}
+
+// chunkLayout 计算分块大小和分块数量
+func chunkLayout(n int, opts []ParallelOptions) (chunkSize int, chunks int) {
+	if n == 0 {
+		return 1, 0
+	}
+	if len(opts) > 0 {
+		chunkSize = opts[0].ChunkSize
+	}
+	if chunkSize < 1 {
+		tasks := parallelWorkers(opts) * 4
+		chunkSize = (n + tasks - 1) / tasks
+	}
+	return chunkSize, (n + chunkSize - 1) / chunkSize
+}
+
+// parallelWorkers 返回配置的worker数量
+func parallelWorkers(opts []ParallelOptions) int {
+	if len(opts) > 0 && opts[0].Workers > 0 {
+		return opts[0].Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// parallelChunks 将[0, n)切分为若干分块，由多个worker并发调用fn处理
+// 任一fn返回错误时取消其余分块并返回第一个错误；ctx在全部分块完成前取消时返回ctx.Err()
+func parallelChunks(ctx context.Context, n int, opts []ParallelOptions, fn func(chunk, start, end int) error) error {
+	chunkSize, chunks := chunkLayout(n, opts)
+	if err := ctx.Err(); err != nil || chunks == 0 {
+		return err
+	}
+	workers := min(parallelWorkers(opts), chunks)
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		next     atomic.Int64
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for workCtx.Err() == nil {
+				chunk := int(next.Add(1) - 1)
+				if chunk >= chunks {
+					return
+				}
+				start := chunk * chunkSize
+				end := min(start+chunkSize, n)
+				if err := fn(chunk, start, end); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	// 分块只有在被领取后才会执行完毕，全部领取说明全部完成
+	if int(next.Load()) >= chunks {
+		return nil
+	}
+	return ctx.Err()
+}
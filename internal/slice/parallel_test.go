@@ -0,0 +1,206 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequence 返回[0, n)的整数切片
+func sequence(n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		result[i] = i
+	}
+	return result
+}
+
+func TestParallelMap(t *testing.T) {
+	src := sequence(10000)
+	expected := Map(src, func(i int) int { return i * 2 })
+
+	testCases := []struct {
+		name string
+		opts ParallelOptions
+	}{
+		{name: "默认配置", opts: ParallelOptions{}},
+		{name: "单个worker", opts: ParallelOptions{Workers: 1}},
+		{name: "小分块", opts: ParallelOptions{Workers: 8, ChunkSize: 7}},
+		{name: "分块大于切片", opts: ParallelOptions{Workers: 4, ChunkSize: 100000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParallelMap(context.Background(), src, func(i int) int { return i * 2 }, tc.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, result)
+		})
+	}
+
+	t.Run("空切片", func(t *testing.T) {
+		result, err := ParallelMap(context.Background(), []int{}, func(i int) int { return i })
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("遇到错误停止", func(t *testing.T) {
+		errBad := errors.New("bad")
+		var calls atomic.Int64
+		result, err := ParallelMapErr(context.Background(), src, func(i int) (int, error) {
+			calls.Add(1)
+			if i == 10 {
+				return 0, errBad
+			}
+			return i, nil
+		}, ParallelOptions{Workers: 1, ChunkSize: 5})
+		assert.ErrorIs(t, err, errBad)
+		assert.Nil(t, result)
+		assert.Equal(t, int64(11), calls.Load())
+	})
+
+	t.Run("已取消的上下文", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result, err := ParallelMap(ctx, src, func(i int) int { return i })
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, result)
+	})
+
+	t.Run("执行中取消", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls atomic.Int64
+		_, err := ParallelMap(ctx, src, func(i int) int {
+			if calls.Add(1) == 100 {
+				cancel()
+			}
+			return i
+		}, ParallelOptions{Workers: 2, ChunkSize: 10})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, calls.Load(), int64(len(src)))
+	})
+}
+
+func TestParallelFilter(t *testing.T) {
+	src := sequence(5000)
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	t.Run("保持顺序", func(t *testing.T) {
+		result, err := ParallelFilter(context.Background(), src, isEven, ParallelOptions{Workers: 4, ChunkSize: 13})
+		assert.NoError(t, err)
+		assert.Equal(t, Filter(src, isEven), result)
+	})
+
+	t.Run("遇到错误停止", func(t *testing.T) {
+		errBad := errors.New("bad")
+		result, err := ParallelFilterErr(context.Background(), src, func(i int) (bool, error) {
+			if i == 4000 {
+				return false, errBad
+			}
+			return true, nil
+		})
+		assert.ErrorIs(t, err, errBad)
+		assert.Nil(t, result)
+	})
+}
+
+func TestParallelForEach(t *testing.T) {
+	src := sequence(1000)
+
+	t.Run("处理全部元素", func(t *testing.T) {
+		var sum atomic.Int64
+		err := ParallelForEach(context.Background(), src, func(i int) {
+			sum.Add(int64(i))
+		}, ParallelOptions{Workers: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(999*1000/2), sum.Load())
+	})
+
+	t.Run("遇到错误停止", func(t *testing.T) {
+		errBad := errors.New("bad")
+		var calls atomic.Int64
+		err := ParallelForEachErr(context.Background(), src, func(i int) error {
+			calls.Add(1)
+			return errBad
+		}, ParallelOptions{Workers: 1, ChunkSize: 10})
+		assert.ErrorIs(t, err, errBad)
+		assert.Equal(t, int64(1), calls.Load())
+	})
+}
+
+func TestParallelReduce(t *testing.T) {
+	t.Run("求和", func(t *testing.T) {
+		src := sequence(10001)
+		sum, err := ParallelReduce(context.Background(), src,
+			func(acc int, i int) int { return acc + i },
+			func(a, b int) int { return a + b },
+			0, ParallelOptions{Workers: 4, ChunkSize: 33})
+		assert.NoError(t, err)
+		assert.Equal(t, 10000*10001/2, sum)
+	})
+
+	t.Run("结合但不可交换", func(t *testing.T) {
+		src := []string{"a", "b", "c", "d", "e", "f", "g"}
+		joined, err := ParallelReduce(context.Background(), src,
+			func(acc string, s string) string { return acc + s },
+			func(a, b string) string { return a + b },
+			"", ParallelOptions{Workers: 3, ChunkSize: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, "abcdefg", joined)
+	})
+
+	t.Run("空切片返回初始值", func(t *testing.T) {
+		sum, err := ParallelReduce(context.Background(), []int{},
+			func(acc int, i int) int { return acc + i },
+			func(a, b int) int { return a + b }, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sum)
+	})
+
+	t.Run("遇到错误停止", func(t *testing.T) {
+		errBad := errors.New("bad")
+		var calls atomic.Int64
+		sum, err := ParallelReduceErr(context.Background(), sequence(1000),
+			func(acc int, i int) (int, error) {
+				calls.Add(1)
+				if i == 5 {
+					return acc, errBad
+				}
+				return acc + i, nil
+			},
+			func(a, b int) int { return a + b },
+			-1, ParallelOptions{Workers: 1, ChunkSize: 10})
+		assert.ErrorIs(t, err, errBad)
+		assert.Equal(t, -1, sum)
+		// 第一个分块出错后不再处理其余分块
+		assert.Equal(t, int64(6), calls.Load())
+	})
+
+	t.Run("错误版本成功时与ParallelReduce一致", func(t *testing.T) {
+		sum, err := ParallelReduceErr(context.Background(), sequence(101),
+			func(acc int, i int) (int, error) { return acc + i, nil },
+			func(a, b int) int { return a + b },
+			0, ParallelOptions{Workers: 4, ChunkSize: 7})
+		assert.NoError(t, err)
+		assert.Equal(t, 5050, sum)
+	})
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	src := sequence(1 << 20)
+	square := func(i int) int { return i * i }
+
+	b.Run("Map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, square)
+		}
+	})
+	b.Run("ParallelMap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ParallelMap(context.Background(), src, square)
+		}
+	})
+}
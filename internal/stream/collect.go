@@ -0,0 +1,58 @@
+package stream
+
+import "iter"
+
+// Reduce 将序列的所有元素聚合为一个值
+// reducer 是一个函数，接受累计值和当前元素，返回新的累计值
+// initial 是初始累计值
+func Reduce[T any, R any](seq iter.Seq[T], reducer func(R, T) R, initial R) R {
+	result := initial
+	for item := range seq {
+		result = reducer(result, item)
+	}
+	return result
+}
+
+// Collect 将序列收集为切片
+func Collect[T any](seq iter.Seq[T]) []T {
+	var result []T
+	for item := range seq {
+		result = append(result, item)
+	}
+	return result
+}
+
+// ToMap 将序列收集为map，键相同时后出现的元素覆盖先出现的
+func ToMap[T any, K comparable, V any](seq iter.Seq[T], key func(T) K, value func(T) V) map[K]V {
+	result := make(map[K]V)
+	for item := range seq {
+		result[key(item)] = value(item)
+	}
+	return result
+}
+
+// GroupBy 按key分组，组内保持元素出现的顺序
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for item := range seq {
+		k := key(item)
+		result[k] = append(result[k], item)
+	}
+	return result
+}
+
+// ForEach 对每个元素执行fn
+func ForEach[T any](seq iter.Seq[T], fn func(T)) {
+	for item := range seq {
+		fn(item)
+	}
+}
+
+// Count 返回序列的元素数量
+func Count[T any](seq iter.Seq[T]) int {
+	count := 0
+	for range seq {
+		count++
+	}
+	return count
+}
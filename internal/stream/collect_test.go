@@ -0,0 +1,41 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollect(t *testing.T) {
+	t.Run("Reduce", func(t *testing.T) {
+		sum := Reduce(Of(1, 2, 3, 4), func(acc, i int) int { return acc + i }, 0)
+		assert.Equal(t, 10, sum)
+
+		joined := Reduce(Of(1, 2, 3), func(acc string, i int) string { return acc + string(rune('0'+i)) }, "")
+		assert.Equal(t, "123", joined)
+	})
+
+	t.Run("Collect空序列", func(t *testing.T) {
+		assert.Empty(t, Collect(Of[int]()))
+	})
+
+	t.Run("ToMap后者覆盖前者", func(t *testing.T) {
+		m := ToMap(Of("a1", "b2", "a3"),
+			func(s string) byte { return s[0] },
+			func(s string) byte { return s[1] })
+		assert.Equal(t, map[byte]byte{'a': '3', 'b': '2'}, m)
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		groups := GroupBy(Of(1, 2, 3, 4, 5), func(i int) bool { return i%2 == 0 })
+		assert.Equal(t, map[bool][]int{true: {2, 4}, false: {1, 3, 5}}, groups)
+	})
+
+	t.Run("ForEach和Count", func(t *testing.T) {
+		var got []int
+		ForEach(Of(1, 2, 3), func(i int) { got = append(got, i) })
+		assert.Equal(t, []int{1, 2, 3}, got)
+		assert.Equal(t, 3, Count(Of(1, 2, 3)))
+		assert.Equal(t, 0, Count(Of[int]()))
+	})
+}
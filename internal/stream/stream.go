@@ -0,0 +1,215 @@
+// Package stream 提供基于iter.Seq的惰性流式操作
+// 中间操作只组装迭代器，元素在终止操作遍历时才逐个计算
+// 容器可通过All()接入流，再通过CollectXxx构造函数收集回容器
+package stream
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Of 返回依次产出values的序列
+func Of[T any](values ...T) iter.Seq[T] {
+	return slices.Values(values)
+}
+
+// Keys 返回由seq中的键组成的序列
+// 可用于将列表、队列的All()转换为下标序列
+func Keys[K any, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values 返回由seq中的值组成的序列
+// 可用于将列表、队列、映射的All()转换为元素序列
+func Values[K any, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map 将每个元素映射为新值
+// mapper 是一个函数，接受原元素并返回新元素
+func Map[T any, R any](seq iter.Seq[T], mapper func(T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for item := range seq {
+			if !yield(mapper(item)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter 过滤元素，只保留满足predicate的元素
+func Filter[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if predicate(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap 将每个元素映射为一个序列，并依次展开
+func FlatMap[T any, R any](seq iter.Seq[T], mapper func(T) iter.Seq[R]) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for item := range seq {
+			for sub := range mapper(item) {
+				if !yield(sub) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Take 只保留前n个元素，n小于1时返回空序列
+// 取满n个后立即停止遍历上游
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n < 1 {
+			return
+		}
+		count := 0
+		for item := range seq {
+			if !yield(item) {
+				return
+			}
+			count++
+			if count >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip 跳过前n个元素
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		count := 0
+		for item := range seq {
+			if count < n {
+				count++
+				continue
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile 保留元素直到第一个不满足predicate的元素为止
+func TakeWhile[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if !predicate(item) || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Distinct 去除重复元素，保留首次出现的顺序
+func Distinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for item := range seq {
+			if _, ok := seen[item]; ok {
+				continue
+			}
+			seen[item] = struct{}{}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk 将元素按size个一组切分，最后一组可能不足size个
+// 每组都是新分配的切片，size小于1时panic
+func Chunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("stream: 分组大小不能小于1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for item := range seq {
+			chunk = append(chunk, item)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window 返回大小为size的滑动窗口，每次向后移动一个元素
+// 元素不足size个时返回空序列，每个窗口都是新分配的切片，size小于1时panic
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("stream: 窗口大小不能小于1")
+	}
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, size)
+		for item := range seq {
+			if len(window) == size {
+				window = window[1:]
+			}
+			window = append(window, item)
+			if len(window) == size {
+				if !yield(slices.Clone(window)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip 将两个序列按位置配对，任一序列结束时停止
+func Zip[A any, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for left := range a {
+			right, ok := next()
+			if !ok || !yield(left, right) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted 按升序排序，需要先读取全部元素
+func Sorted[T cmp.Ordered](seq iter.Seq[T]) iter.Seq[T] {
+	return SortedFunc(seq, cmp.Compare[T])
+}
+
+// SortedFunc 按compare稳定排序，需要先读取全部元素
+func SortedFunc[T any](seq iter.Seq[T], compare func(a, b T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		items := slices.Collect(seq)
+		slices.SortStableFunc(items, compare)
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sword-demon/vtool/internal/list"
+	"github.com/sword-demon/vtool/internal/mapx"
+	"github.com/sword-demon/vtool/internal/queue"
+	"github.com/sword-demon/vtool/internal/set"
+)
+
+// naturals 返回从1开始的无限自然数序列
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Run("Map和Filter", func(t *testing.T) {
+		seq := Map(Filter(Of(1, 2, 3, 4, 5), func(i int) bool { return i%2 == 1 }),
+			func(i int) string { return strings.Repeat("a", i) })
+		assert.Equal(t, []string{"a", "aaa", "aaaaa"}, Collect(seq))
+	})
+
+	t.Run("惰性求值", func(t *testing.T) {
+		calls := 0
+		seq := Map(naturals(), func(i int) int {
+			calls++
+			return i * i
+		})
+		assert.Equal(t, 0, calls)
+
+		assert.Equal(t, []int{1, 4, 9}, Collect(Take(seq, 3)))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("FlatMap", func(t *testing.T) {
+		seq := FlatMap(Of(1, 2, 3), func(i int) iter.Seq[int] {
+			return Take(naturals(), i)
+		})
+		assert.Equal(t, []int{1, 1, 2, 1, 2, 3}, Collect(seq))
+		assert.Equal(t, []int{1, 1}, Collect(Take(seq, 2)))
+	})
+
+	t.Run("Take和Skip", func(t *testing.T) {
+		assert.Equal(t, []int{3, 4}, Collect(Take(Skip(naturals(), 2), 2)))
+		assert.Empty(t, Collect(Take(naturals(), 0)))
+		assert.Equal(t, []int{1, 2}, Collect(Take(Of(1, 2), 5)))
+		assert.Empty(t, Collect(Skip(Of(1, 2), 5)))
+		assert.Equal(t, []int{1, 2}, Collect(Skip(Of(1, 2), -1)))
+	})
+
+	t.Run("TakeWhile", func(t *testing.T) {
+		seq := TakeWhile(naturals(), func(i int) bool { return i < 4 })
+		assert.Equal(t, []int{1, 2, 3}, Collect(seq))
+	})
+
+	t.Run("Distinct", func(t *testing.T) {
+		assert.Equal(t, []int{3, 1, 2}, Collect(Distinct(Of(3, 1, 3, 2, 1))))
+		assert.Equal(t, []int{3, 1}, Collect(Take(Distinct(Of(3, 3, 1, 2)), 2)))
+	})
+
+	t.Run("Chunk", func(t *testing.T) {
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, Collect(Chunk(Of(1, 2, 3, 4, 5), 2)))
+		assert.Empty(t, Collect(Chunk(Of[int](), 2)))
+		assert.Equal(t, [][]int{{1, 2, 3}}, Collect(Take(Chunk(naturals(), 3), 1)))
+		assert.Panics(t, func() { Chunk(Of(1), 0) })
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		windows := Collect(Window(Of(1, 2, 3, 4), 2))
+		assert.Equal(t, [][]int{{1, 2}, {2, 3}, {3, 4}}, windows)
+		assert.Empty(t, Collect(Window(Of(1, 2), 3)))
+		assert.Panics(t, func() { Window(Of(1), 0) })
+	})
+
+	t.Run("Zip", func(t *testing.T) {
+		var pairs []string
+		for n, s := range Zip(naturals(), Of("a", "b", "c")) {
+			pairs = append(pairs, strings.Repeat(s, n))
+		}
+		assert.Equal(t, []string{"a", "bb", "ccc"}, pairs)
+
+		count := 0
+		for range Zip(Of(1, 2, 3), naturals()) {
+			count++
+		}
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, Collect(Sorted(Of(3, 1, 2))))
+
+		words := Of("bb", "a", "cc", "d")
+		byLen := SortedFunc(words, func(a, b string) int { return len(a) - len(b) })
+		assert.Equal(t, []string{"a", "d", "bb", "cc"}, Collect(byLen))
+	})
+}
+
+func TestStreamContainers(t *testing.T) {
+	t.Run("列表到集合", func(t *testing.T) {
+		l := list.NewArrayList[int]()
+		for _, v := range []int{5, 3, 5, 1, 3} {
+			l.Add(v)
+		}
+
+		s := set.CollectTreeSet(Filter(Values(l.All()), func(i int) bool { return i > 1 }))
+		assert.Equal(t, []int{3, 5}, s.ToSlice())
+	})
+
+	t.Run("映射到队列", func(t *testing.T) {
+		m := mapx.NewTreeMap[string, int]()
+		m.Put("b", 2)
+		m.Put("a", 1)
+		m.Put("c", 3)
+
+		q := queue.CollectQueue(Map(Keys(m.All()), strings.ToUpper))
+		assert.Equal(t, 3, q.Size())
+		first, err := q.Dequeue()
+		assert.NoError(t, err)
+		assert.Equal(t, "A", first)
+	})
+
+	t.Run("集合到映射", func(t *testing.T) {
+		s := set.CollectHashSet(Of("go", "java", "rust"))
+		lengths := ToMap(s.All(), func(w string) string { return w }, func(w string) int { return len(w) })
+		assert.Equal(t, map[string]int{"go": 2, "java": 4, "rust": 4}, lengths)
+
+		sl := list.CollectSkipList(Map(s.All(), func(w string) int { return len(w) }))
+		assert.Equal(t, 4, Reduce(sl.All(), func(acc, i int) int { return max(acc, i) }, 0))
+	})
+}
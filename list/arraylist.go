@@ -1,20 +1,25 @@
 package list
 
 import (
-	"cmp"
+	"iter"
 
 	"github.com/sword-demon/vtool/internal/list"
 )
 
 // ArrayList 动态数组
-type ArrayList[T cmp.Ordered] = list.ArrayList[T]
+type ArrayList[T comparable] = list.ArrayList[T]
 
 // NewArrayList 创建新的ArrayList
-func NewArrayList[T cmp.Ordered]() *ArrayList[T] {
+func NewArrayList[T comparable]() *ArrayList[T] {
 	return list.NewArrayList[T]()
 }
 
 // NewArrayListWithCapacity 创建指定初始容量的ArrayList
-func NewArrayListWithCapacity[T cmp.Ordered](capacity int) *ArrayList[T] {
+func NewArrayListWithCapacity[T comparable](capacity int) *ArrayList[T] {
 	return list.NewArrayListWithCapacity[T](capacity)
 }
+
+// CollectArrayList 从迭代器创建ArrayList
+func CollectArrayList[T comparable](seq iter.Seq[T]) *ArrayList[T] {
+	return list.CollectArrayList(seq)
+}
@@ -1,15 +1,20 @@
 package list
 
 import (
-	"cmp"
+	"iter"
 
 	"github.com/sword-demon/vtool/internal/list"
 )
 
 // LinkedList 双向链表
-type LinkedList[T cmp.Ordered] = list.LinkedList[T]
+type LinkedList[T comparable] = list.LinkedList[T]
 
 // NewLinkedList 创建新的双向链表
-func NewLinkedList[T cmp.Ordered]() *LinkedList[T] {
+func NewLinkedList[T comparable]() *LinkedList[T] {
 	return list.NewLinkedList[T]()
 }
+
+// CollectLinkedList 从迭代器创建链表
+func CollectLinkedList[T comparable](seq iter.Seq[T]) *LinkedList[T] {
+	return list.CollectLinkedList(seq)
+}
@@ -2,14 +2,26 @@ package list
 
 import (
 	"cmp"
+	"iter"
 
 	"github.com/sword-demon/vtool/internal/list"
 )
 
 // SkipList 跳表
-type SkipList[T cmp.Ordered] = list.SkipList[T]
+type SkipList[T any] = list.SkipList[T]
 
 // NewSkipList 创建新的跳表
 func NewSkipList[T cmp.Ordered]() *SkipList[T] {
 	return list.NewSkipList[T]()
 }
+
+// NewSkipListFunc 创建使用比较函数compare排序的跳表
+// compare(a, b) 返回负数表示a排在b之前，0表示两个元素相同；compare为nil时panic
+func NewSkipListFunc[T any](compare func(a, b T) int) *SkipList[T] {
+	return list.NewSkipListFunc(compare)
+}
+
+// CollectSkipList 从迭代器创建跳表
+func CollectSkipList[T cmp.Ordered](seq iter.Seq[T]) *SkipList[T] {
+	return list.CollectSkipList(seq)
+}
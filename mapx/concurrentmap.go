@@ -0,0 +1,33 @@
+package mapx
+
+import (
+	"cmp"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
+
+// Map 映射接口，HashMap、LinkedMap和TreeMap都实现了该接口
+type Map[K comparable, V any] = mapx.Map[K, V]
+
+// ConcurrentMap 线程安全的映射
+type ConcurrentMap[K comparable, V any] = mapx.ConcurrentMap[K, V]
+
+// NewConcurrentMap 将m包装为线程安全的映射
+func NewConcurrentMap[K comparable, V any](m Map[K, V]) *ConcurrentMap[K, V] {
+	return mapx.NewConcurrentMap(m)
+}
+
+// NewConcurrentHashMap 创建线程安全的HashMap
+func NewConcurrentHashMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return mapx.NewConcurrentHashMap[K, V]()
+}
+
+// NewConcurrentLinkedMap 创建线程安全的LinkedMap
+func NewConcurrentLinkedMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return mapx.NewConcurrentLinkedMap[K, V]()
+}
+
+// NewConcurrentTreeMap 创建线程安全的TreeMap
+func NewConcurrentTreeMap[K cmp.Ordered, V any]() *ConcurrentMap[K, V] {
+	return mapx.NewConcurrentTreeMap[K, V]()
+}
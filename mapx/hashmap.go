@@ -1,6 +1,10 @@
 package mapx
 
-import "github.com/sword-demon/vtool/internal/mapx"
+import (
+	"iter"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
 
 // HashMap 基于Go内置map实现的增强版映射
 type HashMap[K comparable, V any] = mapx.HashMap[K, V]
@@ -9,3 +13,8 @@ type HashMap[K comparable, V any] = mapx.HashMap[K, V]
 func NewHashMap[K comparable, V any]() *HashMap[K, V] {
 	return mapx.NewHashMap[K, V]()
 }
+
+// CollectHashMap 从迭代器创建HashMap，重复的键保留最后一个值
+func CollectHashMap[K comparable, V any](seq iter.Seq2[K, V]) *HashMap[K, V] {
+	return mapx.CollectHashMap(seq)
+}
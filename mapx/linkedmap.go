@@ -1,6 +1,10 @@
 package mapx
 
-import "github.com/sword-demon/vtool/internal/mapx"
+import (
+	"iter"
+
+	"github.com/sword-demon/vtool/internal/mapx"
+)
 
 // LinkedNode 链表节点
 type LinkedNode[K comparable, V any] = mapx.LinkedNode[K, V]
@@ -12,3 +16,13 @@ type LinkedMap[K comparable, V any] = mapx.LinkedMap[K, V]
 func NewLinkedMap[K comparable, V any]() *LinkedMap[K, V] {
 	return mapx.NewLinkedMap[K, V]()
 }
+
+// NewAccessOrderLinkedMap 创建按访问顺序排列的LinkedMap
+func NewAccessOrderLinkedMap[K comparable, V any]() *LinkedMap[K, V] {
+	return mapx.NewAccessOrderLinkedMap[K, V]()
+}
+
+// CollectLinkedMap 从迭代器创建LinkedMap，重复的键保留最后一个值
+func CollectLinkedMap[K comparable, V any](seq iter.Seq2[K, V]) *LinkedMap[K, V] {
+	return mapx.CollectLinkedMap(seq)
+}
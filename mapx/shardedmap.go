@@ -0,0 +1,25 @@
+package mapx
+
+import "github.com/sword-demon/vtool/internal/mapx"
+
+// Hasher 键的哈希函数，相同的键必须返回相同的哈希值
+type Hasher[K comparable] = mapx.Hasher[K]
+
+// ShardedMap 分片并发哈希映射
+type ShardedMap[K comparable, V any] = mapx.ShardedMap[K, V]
+
+// NewShardedMap 创建分片映射，使用默认的哈希函数
+// shardCount 会向上取整为2的幂，小于等于0时使用默认值32
+func NewShardedMap[K comparable, V any](shardCount int) *ShardedMap[K, V] {
+	return mapx.NewShardedMap[K, V](shardCount)
+}
+
+// NewShardedMapWithHasher 创建使用自定义哈希函数的分片映射
+func NewShardedMapWithHasher[K comparable, V any](shardCount int, hasher Hasher[K]) *ShardedMap[K, V] {
+	return mapx.NewShardedMapWithHasher[K, V](shardCount, hasher)
+}
+
+// DefaultHasher 返回键类型K的默认哈希函数
+func DefaultHasher[K comparable]() Hasher[K] {
+	return mapx.DefaultHasher[K]()
+}
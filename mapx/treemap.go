@@ -2,17 +2,32 @@ package mapx
 
 import (
 	"cmp"
+	"iter"
 
 	"github.com/sword-demon/vtool/internal/mapx"
 )
 
 // TreeNode 树节点
-type TreeNode[K cmp.Ordered, V any] = mapx.TreeNode[K, V]
+type TreeNode[K, V any] = mapx.TreeNode[K, V]
 
-// TreeMap 有序映射，基于二叉搜索树
-type TreeMap[K cmp.Ordered, V any] = mapx.TreeMap[K, V]
+// TreeMap 有序映射，基于红黑树
+type TreeMap[K, V any] = mapx.TreeMap[K, V]
+
+// TreeMapView TreeMap中某个键范围的只读视图，由SubMap、HeadMap、TailMap和DescendingMap返回
+type TreeMapView[K, V any] = mapx.TreeMapView[K, V]
 
 // NewTreeMap 创建新的TreeMap
 func NewTreeMap[K cmp.Ordered, V any]() *TreeMap[K, V] {
 	return mapx.NewTreeMap[K, V]()
 }
+
+// NewTreeMapFunc 创建使用比较函数compare排序的TreeMap
+// compare(a, b) 返回负数表示a排在b之前，0表示两个键相同；compare为nil时panic
+func NewTreeMapFunc[K, V any](compare func(a, b K) int) *TreeMap[K, V] {
+	return mapx.NewTreeMapFunc[K, V](compare)
+}
+
+// CollectTreeMap 从迭代器创建TreeMap，重复的键保留最后一个值
+func CollectTreeMap[K cmp.Ordered, V any](seq iter.Seq2[K, V]) *TreeMap[K, V] {
+	return mapx.CollectTreeMap(seq)
+}
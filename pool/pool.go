@@ -0,0 +1,34 @@
+package pool
+
+import "github.com/sword-demon/vtool/internal/pool"
+
+var (
+	// ErrPoolClosed 协程池已关闭
+	ErrPoolClosed = pool.ErrPoolClosed
+	// ErrPoolOverload 等待队列已满
+	ErrPoolOverload = pool.ErrPoolOverload
+	// ErrTaskPanicked 任务执行时发生panic
+	ErrTaskPanicked = pool.ErrTaskPanicked
+)
+
+// Options 协程池选项
+type Options = pool.Options
+
+// Stats 协程池运行时统计
+type Stats = pool.Stats
+
+// Pool 协程池
+type Pool = pool.Pool
+
+// Future 异步任务的执行结果
+type Future[R any] = pool.Future[R]
+
+// NewPool 创建新的协程池，size为最大协程数
+func NewPool(size int, opts ...Options) *Pool {
+	return pool.NewPool(size, opts...)
+}
+
+// SubmitFunc 提交有返回值的任务，返回对应的Future
+func SubmitFunc[R any](p *Pool, task func() (R, error)) (*Future[R], error) {
+	return pool.SubmitFunc(p, task)
+}
@@ -0,0 +1,22 @@
+package queue
+
+import "github.com/sword-demon/vtool/internal/queue"
+
+// ErrQueueClosed 队列已关闭
+var ErrQueueClosed = queue.ErrQueueClosed
+
+// BlockingQueue 并发阻塞队列
+type BlockingQueue[T any] = queue.BlockingQueue[T]
+
+// NewBlockingQueue 创建新的阻塞队列，capacity小于等于0时表示不限容量
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return queue.NewBlockingQueue[T](capacity)
+}
+
+// BlockingPriorityQueue 并发阻塞优先级队列
+type BlockingPriorityQueue[T any] = queue.BlockingPriorityQueue[T]
+
+// NewBlockingPriorityQueue 创建新的阻塞优先级队列，capacity小于等于0时表示不限容量
+func NewBlockingPriorityQueue[T any](capacity int) *BlockingPriorityQueue[T] {
+	return queue.NewBlockingPriorityQueue[T](capacity)
+}
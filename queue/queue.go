@@ -1,6 +1,10 @@
 package queue
 
-import "github.com/sword-demon/vtool/internal/queue"
+import (
+	"iter"
+
+	"github.com/sword-demon/vtool/internal/queue"
+)
 
 // Queue 普通队列 - FIFO (先进先出)
 type Queue[T any] = queue.Queue[T]
@@ -10,6 +14,11 @@ func NewQueue[T any]() *Queue[T] {
 	return queue.NewQueue[T]()
 }
 
+// CollectQueue 从迭代器创建队列，元素按迭代顺序入队
+func CollectQueue[T any](seq iter.Seq[T]) *Queue[T] {
+	return queue.CollectQueue(seq)
+}
+
 // PriorityItem 优先级队列中的元素
 type PriorityItem[T any] = queue.PriorityItem[T]
 
@@ -20,3 +29,101 @@ type PriorityQueue[T any] = queue.PriorityQueue[T]
 func NewPriorityQueue[T any]() *PriorityQueue[T] {
 	return queue.NewPriorityQueue[T]()
 }
+
+// CollectPriorityQueue 从产出元素和优先级的迭代器创建优先级队列
+func CollectPriorityQueue[T any](seq iter.Seq2[T, int]) *PriorityQueue[T] {
+	return queue.CollectPriorityQueue(seq)
+}
+
+// ConcurrentQueue 无锁并发队列
+type ConcurrentQueue[T any] = queue.ConcurrentQueue[T]
+
+// NewConcurrentQueue 创建新的无锁并发队列
+func NewConcurrentQueue[T any]() *ConcurrentQueue[T] {
+	return queue.NewConcurrentQueue[T]()
+}
+
+// Clock 时钟接口，可在测试中注入假时钟
+type Clock = queue.Clock
+
+// SystemClock 返回基于系统时间的时钟
+func SystemClock() Clock {
+	return queue.SystemClock()
+}
+
+// DelayQueue 延迟队列，元素到期后才能被取出
+type DelayQueue[T any] = queue.DelayQueue[T]
+
+// NewDelayQueue 创建新的延迟队列
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return queue.NewDelayQueue[T]()
+}
+
+// NewDelayQueueWithClock 创建使用指定时钟的延迟队列
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return queue.NewDelayQueueWithClock[T](clock)
+}
+
+// OverflowPolicy 有界队列满时的处理策略
+type OverflowPolicy = queue.OverflowPolicy
+
+const (
+	// RejectNew 拒绝新元素
+	RejectNew = queue.RejectNew
+	// OverwriteOldest 覆盖最旧的元素
+	OverwriteOldest = queue.OverwriteOldest
+)
+
+// NewBoundedQueue 创建有界队列
+// RejectNew策略下队列已满时Enqueue会静默丢弃新元素，需要感知拒绝时使用Offer
+func NewBoundedQueue[T any](capacity int, policy OverflowPolicy) *Queue[T] {
+	return queue.NewBoundedQueue[T](capacity, policy)
+}
+
+// Deque 双端队列
+type Deque[T any] = queue.Deque[T]
+
+// NewDeque 创建新的双端队列
+func NewDeque[T any]() *Deque[T] {
+	return queue.NewDeque[T]()
+}
+
+// CollectDeque 从迭代器创建双端队列，元素依次从队尾加入
+func CollectDeque[T any](seq iter.Seq[T]) *Deque[T] {
+	return queue.CollectDeque(seq)
+}
+
+// Handle 索引优先级队列中元素的句柄
+type Handle[T any] = queue.Handle[T]
+
+// IndexedPriorityQueue 索引优先级队列，支持修改优先级和删除元素
+type IndexedPriorityQueue[T any] = queue.IndexedPriorityQueue[T]
+
+// NewIndexedPriorityQueue 创建新的索引优先级队列
+func NewIndexedPriorityQueue[T any]() *IndexedPriorityQueue[T] {
+	return queue.NewIndexedPriorityQueue[T]()
+}
+
+// FuncHandle 自定义比较索引优先级队列中元素的句柄
+type FuncHandle[T any] = queue.FuncHandle[T]
+
+// IndexedPriorityQueueFunc 使用自定义比较函数的索引优先级队列，支持修改和删除元素
+type IndexedPriorityQueueFunc[T any] = queue.IndexedPriorityQueueFunc[T]
+
+// NewIndexedPriorityQueueFunc 创建使用比较函数cmp的索引优先级队列，cmp(a, b) < 0 表示a优先于b
+func NewIndexedPriorityQueueFunc[T any](cmp func(a, b T) int) *IndexedPriorityQueueFunc[T] {
+	return queue.NewIndexedPriorityQueueFunc(cmp)
+}
+
+// PriorityQueueFunc 使用自定义比较函数的优先级队列
+type PriorityQueueFunc[T any] = queue.PriorityQueueFunc[T]
+
+// NewPriorityQueueFunc 创建使用比较函数cmp的优先级队列，cmp(a, b) < 0 表示a优先于b
+func NewPriorityQueueFunc[T any](cmp func(a, b T) int) *PriorityQueueFunc[T] {
+	return queue.NewPriorityQueueFunc(cmp)
+}
+
+// NewStablePriorityQueueFunc 创建稳定的优先级队列，优先级相同的元素按入队顺序出队
+func NewStablePriorityQueueFunc[T any](cmp func(a, b T) int) *PriorityQueueFunc[T] {
+	return queue.NewStablePriorityQueueFunc(cmp)
+}
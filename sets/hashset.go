@@ -1,6 +1,10 @@
 package sets
 
-import "github.com/sword-demon/vtool/internal/set"
+import (
+	"iter"
+
+	"github.com/sword-demon/vtool/internal/set"
+)
 
 // HashSet 基于map实现的哈希集合
 type HashSet[T comparable] = set.HashSet[T]
@@ -9,3 +13,8 @@ type HashSet[T comparable] = set.HashSet[T]
 func NewHashSet[T comparable]() *HashSet[T] {
 	return set.NewHashSet[T]()
 }
+
+// CollectHashSet 从迭代器创建HashSet
+func CollectHashSet[T comparable](seq iter.Seq[T]) *HashSet[T] {
+	return set.CollectHashSet(seq)
+}
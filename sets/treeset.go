@@ -2,14 +2,26 @@ package sets
 
 import (
 	"cmp"
+	"iter"
 
 	"github.com/sword-demon/vtool/internal/set"
 )
 
 // TreeSet 基于排序切片实现的有序集合
-type TreeSet[T cmp.Ordered] = set.TreeSet[T]
+type TreeSet[T any] = set.TreeSet[T]
 
 // NewTreeSet 创建新的TreeSet
 func NewTreeSet[T cmp.Ordered]() *TreeSet[T] {
 	return set.NewTreeSet[T]()
 }
+
+// NewTreeSetFunc 创建使用比较函数compare排序的TreeSet
+// compare(a, b) 返回负数表示a排在b之前，0表示两个元素相同；compare为nil时panic
+func NewTreeSetFunc[T any](compare func(a, b T) int) *TreeSet[T] {
+	return set.NewTreeSetFunc(compare)
+}
+
+// CollectTreeSet 从迭代器创建TreeSet
+func CollectTreeSet[T cmp.Ordered](seq iter.Seq[T]) *TreeSet[T] {
+	return set.CollectTreeSet(seq)
+}
@@ -0,0 +1,51 @@
+package slice
+
+import (
+	"context"
+
+	"github.com/sword-demon/vtool/internal/slice"
+)
+
+// ParallelOptions 并行操作的配置
+type ParallelOptions = slice.ParallelOptions
+
+// ParallelMap 并行地将每个元素映射为新值，结果顺序与src一致
+func ParallelMap[Src any, Dst any](ctx context.Context, src []Src, mapper func(Src) Dst, opts ...ParallelOptions) ([]Dst, error) {
+	return slice.ParallelMap(ctx, src, mapper, opts...)
+}
+
+// ParallelMapErr 并行映射，mapper返回错误时停止并返回第一个错误
+func ParallelMapErr[Src any, Dst any](ctx context.Context, src []Src, mapper func(Src) (Dst, error), opts ...ParallelOptions) ([]Dst, error) {
+	return slice.ParallelMapErr(ctx, src, mapper, opts...)
+}
+
+// ParallelFilter 并行地过滤元素，结果顺序与src一致
+func ParallelFilter[Src any](ctx context.Context, src []Src, predicate func(Src) bool, opts ...ParallelOptions) ([]Src, error) {
+	return slice.ParallelFilter(ctx, src, predicate, opts...)
+}
+
+// ParallelFilterErr 并行过滤，predicate返回错误时停止并返回第一个错误
+func ParallelFilterErr[Src any](ctx context.Context, src []Src, predicate func(Src) (bool, error), opts ...ParallelOptions) ([]Src, error) {
+	return slice.ParallelFilterErr(ctx, src, predicate, opts...)
+}
+
+// ParallelForEach 并行地对每个元素执行fn，执行顺序不确定
+func ParallelForEach[Src any](ctx context.Context, src []Src, fn func(Src), opts ...ParallelOptions) error {
+	return slice.ParallelForEach(ctx, src, fn, opts...)
+}
+
+// ParallelForEachErr 并行执行fn，fn返回错误时停止并返回第一个错误
+func ParallelForEachErr[Src any](ctx context.Context, src []Src, fn func(Src) error, opts ...ParallelOptions) error {
+	return slice.ParallelForEachErr(ctx, src, fn, opts...)
+}
+
+// ParallelReduce 并行地将切片聚合为一个值
+// combiner必须满足结合律，initial必须是combiner的单位元
+func ParallelReduce[Src any, R any](ctx context.Context, src []Src, reducer func(R, Src) R, combiner func(R, R) R, initial R, opts ...ParallelOptions) (R, error) {
+	return slice.ParallelReduce(ctx, src, reducer, combiner, initial, opts...)
+}
+
+// ParallelReduceErr 与ParallelReduce相同，reducer返回错误时停止并返回第一个错误
+func ParallelReduceErr[Src any, R any](ctx context.Context, src []Src, reducer func(R, Src) (R, error), combiner func(R, R) R, initial R, opts ...ParallelOptions) (R, error) {
+	return slice.ParallelReduceErr(ctx, src, reducer, combiner, initial, opts...)
+}
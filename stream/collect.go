@@ -0,0 +1,37 @@
+package stream
+
+import (
+	"iter"
+
+	"github.com/sword-demon/vtool/internal/stream"
+)
+
+// Reduce 将序列的所有元素聚合为一个值
+func Reduce[Src any, R any](seq iter.Seq[Src], reducer func(R, Src) R, initial R) R {
+	return stream.Reduce(seq, reducer, initial)
+}
+
+// Collect 将序列收集为切片
+func Collect[T any](seq iter.Seq[T]) []T {
+	return stream.Collect(seq)
+}
+
+// ToMap 将序列收集为map，键相同时后出现的元素覆盖先出现的
+func ToMap[T any, K comparable, V any](seq iter.Seq[T], key func(T) K, value func(T) V) map[K]V {
+	return stream.ToMap(seq, key, value)
+}
+
+// GroupBy 按key分组，组内保持元素出现的顺序
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	return stream.GroupBy(seq, key)
+}
+
+// ForEach 对每个元素执行fn
+func ForEach[T any](seq iter.Seq[T], fn func(T)) {
+	stream.ForEach(seq, fn)
+}
+
+// Count 返回序列的元素数量
+func Count[T any](seq iter.Seq[T]) int {
+	return stream.Count(seq)
+}
@@ -0,0 +1,86 @@
+// Package stream 提供基于iter.Seq的惰性流式操作
+// 中间操作只组装迭代器，元素在终止操作遍历时才逐个计算
+// 容器可通过All()接入流，再通过CollectXxx构造函数收集回容器
+package stream
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/sword-demon/vtool/internal/stream"
+)
+
+// Of 返回依次产出values的序列
+func Of[T any](values ...T) iter.Seq[T] {
+	return stream.Of(values...)
+}
+
+// Keys 返回由seq中的键组成的序列
+func Keys[K any, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return stream.Keys(seq)
+}
+
+// Values 返回由seq中的值组成的序列
+func Values[K any, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return stream.Values(seq)
+}
+
+// Map 将每个元素映射为新值
+func Map[Src any, Dst any](seq iter.Seq[Src], mapper func(Src) Dst) iter.Seq[Dst] {
+	return stream.Map(seq, mapper)
+}
+
+// Filter 过滤元素，只保留满足predicate的元素
+func Filter[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return stream.Filter(seq, predicate)
+}
+
+// FlatMap 将每个元素映射为一个序列，并依次展开
+func FlatMap[Src any, Dst any](seq iter.Seq[Src], mapper func(Src) iter.Seq[Dst]) iter.Seq[Dst] {
+	return stream.FlatMap(seq, mapper)
+}
+
+// Take 只保留前n个元素
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return stream.Take(seq, n)
+}
+
+// Skip 跳过前n个元素
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return stream.Skip(seq, n)
+}
+
+// TakeWhile 保留元素直到第一个不满足predicate的元素为止
+func TakeWhile[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return stream.TakeWhile(seq, predicate)
+}
+
+// Distinct 去除重复元素，保留首次出现的顺序
+func Distinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return stream.Distinct(seq)
+}
+
+// Chunk 将元素按size个一组切分，size小于1时panic
+func Chunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return stream.Chunk(seq, size)
+}
+
+// Window 返回大小为size的滑动窗口，size小于1时panic
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return stream.Window(seq, size)
+}
+
+// Zip 将两个序列按位置配对，任一序列结束时停止
+func Zip[A any, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return stream.Zip(a, b)
+}
+
+// Sorted 按升序排序
+func Sorted[T cmp.Ordered](seq iter.Seq[T]) iter.Seq[T] {
+	return stream.Sorted(seq)
+}
+
+// SortedFunc 按compare稳定排序
+func SortedFunc[T any](seq iter.Seq[T], compare func(a, b T) int) iter.Seq[T] {
+	return stream.SortedFunc(seq, compare)
+}